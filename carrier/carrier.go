@@ -12,44 +12,100 @@ package carrier
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff"
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-election/contract"
 	"github.com/iotexproject/iotex-election/types"
 )
 
+var (
+	ethRPCDurationMtc = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iotex_election_eth_rpc_duration_seconds",
+			Help:    "Latency of the calls to gravity chain endpoints",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"endpoint", "host"},
+	)
+	ethRPCErrorMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_election_eth_rpc_errors_total",
+			Help: "Number of failed calls to gravity chain endpoints",
+		},
+		[]string{"endpoint", "host"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(ethRPCDurationMtc, ethRPCErrorMtc)
+}
+
 // Carrier defines an interfact to fetch votes
 type Carrier interface {
 	// BlockTimestamp returns the timestamp of a block
 	BlockTimestamp(uint64) (time.Time, error)
+	// BlockHash returns the hash of a block
+	BlockHash(uint64) (common.Hash, error)
 	// SubscribeNewBlock callbacks on new block created
 	SubscribeNewBlock(chan uint64, chan error, chan bool)
 	// HasStakingEvents returns true if there is any staking related events or error
 	HasStakingEvents(*big.Int, *big.Int) bool
 	// Tip returns the latest height and its timestamp
 	Tip() (uint64, error)
-	// Registrations returns the candidate registrations on height
-	Registrations(uint64, *big.Int, uint8) (*big.Int, []*types.Registration, error)
-	// Buckets returns the buckets on height
-	Buckets(uint64, *big.Int, uint8) (*big.Int, []*types.Bucket, error)
+	// Registrations returns the candidate registrations on height, read at the block of the hash if not empty
+	Registrations(uint64, common.Hash, *big.Int, uint8) (*big.Int, []*types.Registration, error)
+	// Buckets returns the buckets on height, read at the block of the hash if not empty
+	Buckets(uint64, common.Hash, *big.Int, uint8) (*big.Int, []*types.Bucket, error)
+	// Rollback drops the data cached above height, after the chain is reorganized
+	Rollback(uint64)
+	// Endpoints returns the reachability of the gravity chain endpoints
+	Endpoints() []EndpointStatus
 	// Close closes carrier
 	Close()
 }
 
+// EndpointStatus defines the reachability of an endpoint by its last call. An endpoint never called has a zero
+// LastCheck.
+type EndpointStatus struct {
+	URL       string
+	Reachable bool
+	LastError string
+	LastCheck time.Time
+}
+
+// QuorumConfig defines the number of clients to read from and the number of matching responses required
+type QuorumConfig struct {
+	Size      int
+	Threshold int
+}
+
 // EthClientPool defines a set of ethereum clients with execute interface
 type EthClientPool struct {
-	clientURLs []string
-	client     *ethclient.Client
-	lock       sync.RWMutex
+	clientURLs    []string
+	client        *ethclient.Client
+	clientURL     string
+	lock          sync.RWMutex
+	quorumClients []*ethclient.Client
+	quorumLock    sync.Mutex
+	statuses      map[string]*EndpointStatus
+	statusLock    sync.RWMutex
 }
 
 // NewEthClientPool creates a new pool
@@ -57,15 +113,24 @@ func NewEthClientPool(urls []string) *EthClientPool {
 	return &EthClientPool{
 		clientURLs: urls,
 		client:     nil,
+		statuses:   map[string]*EndpointStatus{},
 	}
 }
 
 // Close closes the current client if available
 func (pool *EthClientPool) Close() {
-	pool.swapClient(nil)
+	pool.swapClient(nil, "")
+	pool.quorumLock.Lock()
+	defer pool.quorumLock.Unlock()
+	for i, client := range pool.quorumClients {
+		if client != nil {
+			client.Close()
+			pool.quorumClients[i] = nil
+		}
+	}
 }
 
-func (pool *EthClientPool) swapClient(client *ethclient.Client) {
+func (pool *EthClientPool) swapClient(client *ethclient.Client, url string) {
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
 	if pool.client != client {
@@ -73,29 +138,99 @@ func (pool *EthClientPool) swapClient(client *ethclient.Client) {
 			pool.client.Close()
 		}
 		pool.client = client
+		pool.clientURL = url
 	}
 }
 
-func (pool *EthClientPool) execute(callback func(c *ethclient.Client) error, client *ethclient.Client) error {
+func (pool *EthClientPool) execute(callback func(c *ethclient.Client) error, client *ethclient.Client, url string) error {
 	pool.lock.RLock()
 	defer pool.lock.RUnlock()
 	if client != nil {
-		return callback(client)
+		return pool.observe(url, func() error { return callback(client) })
 	}
 	if pool.client != nil {
-		return callback(pool.client)
+		return pool.observe(pool.clientURL, func() error { return callback(pool.client) })
 	}
 	return errors.New("no client available")
 }
 
+// observe records the latency and the error of a call to the endpoint of url, which is labeled by its index and host
+// rather than the url carrying the api key
+func (pool *EthClientPool) observe(url string, call func() error) error {
+	start := time.Now()
+	err := call()
+	endpoint, host := pool.endpointLabels(url)
+	ethRPCDurationMtc.WithLabelValues(endpoint, host).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ethRPCErrorMtc.WithLabelValues(endpoint, host).Inc()
+	}
+	pool.updateStatus(url, err)
+	return err
+}
+
+func (pool *EthClientPool) endpointLabels(url string) (string, string) {
+	for i, clientURL := range pool.clientURLs {
+		if clientURL == url {
+			return strconv.Itoa(i), RedactURL(url)
+		}
+	}
+	return "", RedactURL(url)
+}
+
+// RedactURL returns the scheme and host of a url, dropping the user info, path, and query, which may carry secrets
+func RedactURL(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		// an address without scheme is parsed as an authority
+		rawURL = "//" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "redacted"
+	}
+	if u.Scheme == "" {
+		return u.Host
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func (pool *EthClientPool) updateStatus(url string, err error) {
+	status := &EndpointStatus{
+		URL:       url,
+		Reachable: err == nil,
+		LastCheck: time.Now(),
+	}
+	if err != nil {
+		status.LastError = err.Error()
+	}
+	pool.statusLock.Lock()
+	defer pool.statusLock.Unlock()
+	pool.statuses[url] = status
+}
+
+// Endpoints returns the reachability of the client urls
+func (pool *EthClientPool) Endpoints() []EndpointStatus {
+	pool.statusLock.RLock()
+	defer pool.statusLock.RUnlock()
+	retval := make([]EndpointStatus, 0, len(pool.clientURLs))
+	for _, url := range pool.clientURLs {
+		if status, ok := pool.statuses[url]; ok {
+			retval = append(retval, *status)
+		} else {
+			retval = append(retval, EndpointStatus{URL: url})
+		}
+	}
+	return retval
+}
+
 // Execute executes callback by rotating all client urls
 func (pool *EthClientPool) Execute(callback func(c *ethclient.Client) error) (err error) {
-	if err = pool.execute(callback, nil); err == nil {
+	if err = pool.execute(callback, nil, ""); err == nil {
 		return
 	}
 	var client *ethclient.Client
 	for i := 0; i < len(pool.clientURLs); i++ {
 		if client, err = ethclient.Dial(pool.clientURLs[i]); err != nil {
+			pool.updateStatus(pool.clientURLs[i], err)
 			zap.L().Error(
 				"client is not reachable",
 				zap.String("url", pool.clientURLs[i]),
@@ -103,18 +238,152 @@ func (pool *EthClientPool) Execute(callback func(c *ethclient.Client) error) (er
 			)
 			continue
 		}
-		if err = pool.execute(callback, client); err == nil {
-			pool.swapClient(client)
+		if err = pool.execute(callback, client, pool.clientURLs[i]); err == nil {
+			pool.swapClient(client, pool.clientURLs[i])
 			return
 		}
 	}
 	return errors.Wrap(err, "failed to execute callback with any client")
 }
 
+// ExecuteQuorum executes callback with the clients of the first size urls, and returns the response
+// matched by at least threshold of them. The urls of the clients disagreeing with the quorum are reported.
+func (pool *EthClientPool) ExecuteQuorum(
+	size int,
+	threshold int,
+	callback func(c *ethclient.Client) (interface{}, error),
+) (interface{}, error) {
+	results, errs, err := pool.executeAll(size, callback)
+	if err != nil {
+		return nil, err
+	}
+	result, dissenters, err := agree(results, errs, threshold)
+	if len(dissenters) != 0 {
+		urls := make([]string, 0, len(dissenters))
+		for _, i := range dissenters {
+			urls = append(urls, pool.clientURLs[i])
+		}
+		zap.L().Warn("clients disagree with quorum", zap.Strings("urls", urls))
+		if err != nil {
+			return nil, errors.Wrapf(err, "disagreeing clients %v", urls)
+		}
+	}
+	return result, err
+}
+
+// executeAll executes callback with the clients of the first size urls in parallel, and returns their responses
+func (pool *EthClientPool) executeAll(
+	size int,
+	callback func(c *ethclient.Client) (interface{}, error),
+) ([]interface{}, []error, error) {
+	if size > len(pool.clientURLs) {
+		return nil, nil, errors.Errorf("quorum size %d is larger than the number of clients %d", size, len(pool.clientURLs))
+	}
+	results := make([]interface{}, size)
+	errs := make([]error, size)
+	var wg sync.WaitGroup
+	for i := 0; i < size; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := pool.quorumClient(i)
+			if err != nil {
+				pool.updateStatus(pool.clientURLs[i], err)
+				errs[i] = err
+				return
+			}
+			errs[i] = pool.observe(pool.clientURLs[i], func() (err error) {
+				results[i], err = callback(client)
+				return
+			})
+			if errs[i] != nil {
+				pool.resetQuorumClient(i, client)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results, errs, nil
+}
+
+func (pool *EthClientPool) quorumClient(i int) (*ethclient.Client, error) {
+	pool.quorumLock.Lock()
+	defer pool.quorumLock.Unlock()
+	if pool.quorumClients == nil {
+		pool.quorumClients = make([]*ethclient.Client, len(pool.clientURLs))
+	}
+	if pool.quorumClients[i] == nil {
+		client, err := ethclient.Dial(pool.clientURLs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "client %s is not reachable", pool.clientURLs[i])
+		}
+		pool.quorumClients[i] = client
+	}
+	return pool.quorumClients[i], nil
+}
+
+func (pool *EthClientPool) resetQuorumClient(i int, client *ethclient.Client) {
+	pool.quorumLock.Lock()
+	defer pool.quorumLock.Unlock()
+	if pool.quorumClients[i] == client {
+		client.Close()
+		pool.quorumClients[i] = nil
+	}
+}
+
+// agree groups the results by their serialization, and returns the one shared by at least threshold of them,
+// together with the indexes of the results not matching it
+func agree(results []interface{}, errs []error, threshold int) (interface{}, []int, error) {
+	keys := make([]string, len(results))
+	counts := map[string]int{}
+	for i, result := range results {
+		if errs[i] != nil {
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys[i] = string(data)
+		counts[keys[i]]++
+	}
+	var majority string
+	for key, count := range counts {
+		if count > counts[majority] || (count == counts[majority] && key < majority) {
+			majority = key
+		}
+	}
+	var result interface{}
+	dissenters := []int{}
+	for i := range results {
+		if errs[i] != nil || keys[i] != majority {
+			dissenters = append(dissenters, i)
+			continue
+		}
+		if result == nil {
+			result = results[i]
+		}
+	}
+	if counts[majority] < threshold {
+		var err error
+		for _, e := range errs {
+			if e != nil {
+				err = e
+				break
+			}
+		}
+		if err == nil {
+			err = errors.New("mismatched responses")
+		}
+		return nil, dissenters, errors.Wrapf(err, "only %d of %d clients agree, %d required", counts[majority], len(results), threshold)
+	}
+	return result, dissenters, nil
+}
+
 type ethereumCarrier struct {
 	confirmHeight           uint64
 	tickerDuration          time.Duration
 	ethClientPool           *EthClientPool
+	quorum                  QuorumConfig
 	stakingContractAddress  common.Address
 	registerContractAddress common.Address
 }
@@ -126,35 +395,85 @@ func NewEthereumVoteCarrier(
 	clientURLs []string,
 	registerContractAddress common.Address,
 	stakingContractAddress common.Address,
+	quorum QuorumConfig,
 ) (Carrier, error) {
 	if len(clientURLs) == 0 {
 		return nil, errors.New("client URL list is empty")
 	}
+	if quorum.Size > 1 {
+		if quorum.Size > len(clientURLs) {
+			return nil, errors.Errorf("quorum size %d is larger than the number of client URLs %d", quorum.Size, len(clientURLs))
+		}
+		if quorum.Threshold <= 0 || quorum.Threshold > quorum.Size {
+			return nil, errors.Errorf("invalid quorum threshold %d of %d", quorum.Threshold, quorum.Size)
+		}
+	}
 	return &ethereumCarrier{
 		confirmHeight:           confirmHeight,
 		tickerDuration:          tickerDuration,
 		ethClientPool:           NewEthClientPool(clientURLs),
+		quorum:                  quorum,
 		stakingContractAddress:  stakingContractAddress,
 		registerContractAddress: registerContractAddress,
 	}, nil
 }
 
+func (evc *ethereumCarrier) Endpoints() []EndpointStatus {
+	return evc.ethClientPool.Endpoints()
+}
+
 func (evc *ethereumCarrier) Close() {
 	evc.ethClientPool.Close()
 }
 
-func (evc *ethereumCarrier) BlockTimestamp(height uint64) (ts time.Time, err error) {
+// Rollback does nothing since the data are read from the chain on each call
+func (evc *ethereumCarrier) Rollback(uint64) {}
+
+// execute executes callback on the quorum of clients if enabled, otherwise on any available client
+func (evc *ethereumCarrier) execute(callback func(c *ethclient.Client) (interface{}, error)) (retval interface{}, err error) {
+	if evc.quorum.Size > 1 {
+		return evc.ethClientPool.ExecuteQuorum(evc.quorum.Size, evc.quorum.Threshold, callback)
+	}
 	err = evc.ethClientPool.Execute(func(client *ethclient.Client) error {
+		var err error
+		retval, err = callback(client)
+		return err
+	})
+	return
+}
+
+func (evc *ethereumCarrier) BlockTimestamp(height uint64) (time.Time, error) {
+	retval, err := evc.execute(func(client *ethclient.Client) (interface{}, error) {
 		header, err := client.HeaderByNumber(
 			context.Background(),
 			big.NewInt(0).SetUint64(height),
 		)
-		if err == nil {
-			ts = time.Unix(int64(header.Time), 0)
+		if err != nil {
+			return nil, err
 		}
-		return err
+		return time.Unix(int64(header.Time), 0), nil
 	})
-	return
+	if err != nil {
+		return time.Time{}, err
+	}
+	return retval.(time.Time), nil
+}
+
+func (evc *ethereumCarrier) BlockHash(height uint64) (common.Hash, error) {
+	retval, err := evc.execute(func(client *ethclient.Client) (interface{}, error) {
+		header, err := client.HeaderByNumber(
+			context.Background(),
+			big.NewInt(0).SetUint64(height),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return header.Hash(), nil
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return retval.(common.Hash), nil
 }
 
 func (evc *ethereumCarrier) SubscribeNewBlock(
@@ -162,6 +481,10 @@ func (evc *ethereumCarrier) SubscribeNewBlock(
 	report chan error,
 	unsubscribe chan bool,
 ) {
+	if urls := websocketURLs(evc.ethClientPool.clientURLs); len(urls) != 0 {
+		go evc.subscribeNewHead(urls, tipChan, report, unsubscribe)
+		return
+	}
 	ticker := time.NewTicker(evc.tickerDuration)
 	lastHeight := uint64(0)
 	go func() {
@@ -180,63 +503,232 @@ func (evc *ethereumCarrier) SubscribeNewBlock(
 	}()
 }
 
+// subscribeNewHead subscribes to new heads via websocket, and reconnects with backoff on failure. On each
+// (re)connection, the confirmed tip is reported first, such that the heights missed while disconnected are synced.
+func (evc *ethereumCarrier) subscribeNewHead(
+	urls []string,
+	tipChan chan uint64,
+	report chan error,
+	unsubscribe chan bool,
+) {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+	lastHeight := uint64(0)
+	notify := func(height uint64) bool {
+		if height <= evc.confirmHeight || height-evc.confirmHeight <= lastHeight {
+			return true
+		}
+		lastHeight = height - evc.confirmHeight
+		select {
+		case <-unsubscribe:
+			return false
+		case tipChan <- lastHeight:
+			return true
+		}
+	}
+	for i := 0; ; i = (i + 1) % len(urls) {
+		stopped, err := watchNewHead(urls[i], bo, notify, unsubscribe)
+		if stopped {
+			return
+		}
+		select {
+		case <-unsubscribe:
+			return
+		case report <- errors.Wrapf(err, "new head subscription to %s failed", urls[i]):
+		}
+		select {
+		case <-unsubscribe:
+			return
+		case <-time.After(bo.NextBackOff()):
+		}
+	}
+}
+
+// watchNewHead notifies the latest height and the heights of new heads, until unsubscribed or disconnected
+func watchNewHead(
+	url string,
+	bo backoff.BackOff,
+	notify func(uint64) bool,
+	unsubscribe chan bool,
+) (bool, error) {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	headers := make(chan *ethtypes.Header)
+	sub, err := client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return false, err
+	}
+	defer sub.Unsubscribe()
+	bo.Reset()
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return false, err
+	}
+	if !notify(header.Number.Uint64()) {
+		return true, nil
+	}
+	for {
+		select {
+		case <-unsubscribe:
+			return true, nil
+		case header := <-headers:
+			if !notify(header.Number.Uint64()) {
+				return true, nil
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return false, err
+		}
+	}
+}
+
+func websocketURLs(urls []string) []string {
+	retval := []string{}
+	for _, url := range urls {
+		if strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://") {
+			retval = append(retval, url)
+		}
+	}
+	return retval
+}
+
 func (evc *ethereumCarrier) Tip() (uint64, error) {
 	return evc.tip(0)
 }
 
-func (evc *ethereumCarrier) tip(lastHeight uint64) (tip uint64, err error) {
-	if err = evc.ethClientPool.Execute(func(client *ethclient.Client) error {
+func (evc *ethereumCarrier) tip(lastHeight uint64) (uint64, error) {
+	height, err := evc.latestHeight()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get tip height")
+	}
+	if height <= lastHeight+evc.confirmHeight {
+		return 0, errors.Errorf(
+			"failed to get tip height: client is out of date, client height %d < last height %d",
+			height,
+			lastHeight,
+		)
+	}
+	return height - evc.confirmHeight, nil
+}
+
+// latestHeight returns the latest height of any available client, or the highest one reached by at least threshold
+// of the quorum clients if enabled, such that a single client could not report a height the others do not have
+func (evc *ethereumCarrier) latestHeight() (uint64, error) {
+	latest := func(client *ethclient.Client) (interface{}, error) {
 		header, err := client.HeaderByNumber(context.Background(), nil)
-		if err == nil {
-			if header.Number.Uint64() > lastHeight+evc.confirmHeight {
-				tip = header.Number.Uint64() - evc.confirmHeight
-				return nil
-			}
-			err = errors.Errorf(
-				"client is out of date, client height %d < last height %d",
-				header.Number.Uint64(),
-				lastHeight,
-			)
+		if err != nil {
+			return nil, err
 		}
-		return err
-	}); err != nil {
-		err = errors.Wrap(err, "failed to get tip height")
+		return header.Number.Uint64(), nil
 	}
-	return
+	if evc.quorum.Size <= 1 {
+		retval, err := evc.execute(latest)
+		if err != nil {
+			return 0, err
+		}
+		return retval.(uint64), nil
+	}
+	results, errs, err := evc.ethClientPool.executeAll(evc.quorum.Size, latest)
+	if err != nil {
+		return 0, err
+	}
+	return reachedHeight(results, errs, evc.quorum.Threshold)
 }
 
-func (evc *ethereumCarrier) candidates(
-	opts *bind.CallOpts,
-	startIndex *big.Int,
-	limit *big.Int,
-) (result struct {
+// reachedHeight returns the highest height reached by at least threshold of the responses
+func reachedHeight(results []interface{}, errs []error, threshold int) (uint64, error) {
+	heights := []uint64{}
+	var lastErr error
+	for i, result := range results {
+		if errs[i] != nil {
+			lastErr = errs[i]
+			continue
+		}
+		heights = append(heights, result.(uint64))
+	}
+	if threshold < 1 || len(heights) < threshold {
+		if lastErr == nil {
+			lastErr = errors.New("no response")
+		}
+		return 0, errors.Wrapf(
+			lastErr,
+			"only %d of %d clients respond, %d required",
+			len(heights),
+			len(results),
+			threshold,
+		)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] > heights[j]
+	})
+	return heights[threshold-1], nil
+}
+
+// callOpts returns the options to call at the block of the hash, or at height if the hash is empty. A call pinned
+// to a hash fails on the endpoints which do not have the block, rather than reading the state of another fork.
+func callOpts(height uint64, blockHash common.Hash) *bind.CallOpts {
+	if blockHash != (common.Hash{}) {
+		return &bind.CallOpts{BlockHash: blockHash}
+	}
+	return &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(height)}
+}
+
+// EthereumCandidatesResult defines the data structure the candidates api returns
+type EthereumCandidatesResult struct {
 	Names          [][12]byte
 	Addresses      []common.Address
 	IoOperatorAddr [][32]byte
 	IoRewardAddr   [][32]byte
 	Weights        []*big.Int
-}, err error) {
-	if err = evc.ethClientPool.Execute(func(client *ethclient.Client) error {
-		if caller, err := contract.NewRegisterCaller(evc.registerContractAddress, client); err == nil {
-			var count *big.Int
-			if count, err = caller.CandidateCount(opts); err != nil {
-				return err
-			}
-			if startIndex.Cmp(count) >= 0 {
-				return nil
-			}
-			result, err = caller.GetAllCandidates(opts, startIndex, limit)
+}
+
+func (evc *ethereumCarrier) candidates(
+	opts *bind.CallOpts,
+	startIndex *big.Int,
+	limit *big.Int,
+) (result EthereumCandidatesResult, err error) {
+	var retval interface{}
+	if retval, err = evc.execute(func(client *ethclient.Client) (interface{}, error) {
+		var r EthereumCandidatesResult
+		caller, err := contract.NewRegisterCaller(evc.registerContractAddress, client)
+		if err != nil {
+			return nil, err
 		}
-		return err
+		var count *big.Int
+		if count, err = caller.CandidateCount(opts); err != nil {
+			return nil, err
+		}
+		if startIndex.Cmp(count) >= 0 {
+			return r, nil
+		}
+		r, err = caller.GetAllCandidates(opts, startIndex, limit)
+		return r, err
 	}); err != nil {
 		err = errors.Wrap(err, "failed to get candidates")
+		return
 	}
+	result = retval.(EthereumCandidatesResult)
 	return
 }
 
+// FilterLogs returns the logs matching the query, which are agreed by the quorum if enabled
+func (evc *ethereumCarrier) FilterLogs(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	retval, err := evc.execute(func(client *ethclient.Client) (interface{}, error) {
+		return client.FilterLogs(context.Background(), query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retval.([]ethtypes.Log), nil
+}
+
 func (evc *ethereumCarrier) HasStakingEvents(from *big.Int, to *big.Int) bool {
-	retval := true
-	if err := evc.ethClientPool.Execute(func(client *ethclient.Client) error {
+	retval, err := evc.execute(func(client *ethclient.Client) (interface{}, error) {
 		logs, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{
 			FromBlock: from,
 			ToBlock:   to,
@@ -249,18 +741,20 @@ func (evc *ethereumCarrier) HasStakingEvents(from *big.Int, to *big.Int) bool {
 				},
 			},
 		})
-		if err == nil {
-			retval = len(logs) != 0
+		if err != nil {
+			return nil, err
 		}
-		return err
-	}); err != nil {
+		return len(logs) != 0, nil
+	})
+	if err != nil {
 		return true
 	}
-	return retval
+	return retval.(bool)
 }
 
 func (evc *ethereumCarrier) Registrations(
 	height uint64,
+	blockHash common.Hash,
 	startIndex *big.Int,
 	count uint8,
 ) (*big.Int, []*types.Registration, error) {
@@ -268,7 +762,7 @@ func (evc *ethereumCarrier) Registrations(
 		startIndex = big.NewInt(1)
 	}
 	retval, err := evc.candidates(
-		&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(height)},
+		callOpts(height, blockHash),
 		startIndex,
 		big.NewInt(int64(count)),
 	)
@@ -317,10 +811,12 @@ func (evc *ethereumCarrier) buckets(
 	previousIndex *big.Int,
 	limit *big.Int,
 ) (result EthereumBucketsResult, err error) {
-	if err = evc.ethClientPool.Execute(func(client *ethclient.Client) error {
+	var retval interface{}
+	if retval, err = evc.execute(func(client *ethclient.Client) (interface{}, error) {
+		var r EthereumBucketsResult
 		caller, err := contract.NewStakingCaller(evc.stakingContractAddress, client)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		var bucket struct {
 			CanName          [12]byte
@@ -334,21 +830,25 @@ func (evc *ethereumCarrier) buckets(
 			Prev             *big.Int
 			Next             *big.Int
 		}
-		if bucket, err = caller.Buckets(opts, previousIndex); err == nil {
-			if bucket.Next.Cmp(big.NewInt(0)) <= 0 {
-				return nil
-			}
-			result, err = caller.GetActiveBuckets(opts, previousIndex, limit)
+		if bucket, err = caller.Buckets(opts, previousIndex); err != nil {
+			return nil, err
 		}
-		return err
+		if bucket.Next.Cmp(big.NewInt(0)) <= 0 {
+			return r, nil
+		}
+		r, err = caller.GetActiveBuckets(opts, previousIndex, limit)
+		return r, err
 	}); err != nil {
 		err = errors.Wrap(err, "failed to get votes")
+		return
 	}
+	result = retval.(EthereumBucketsResult)
 	return
 }
 
 func (evc *ethereumCarrier) Buckets(
 	height uint64,
+	blockHash common.Hash,
 	previousIndex *big.Int,
 	count uint8,
 ) (*big.Int, []*types.Bucket, error) {
@@ -356,7 +856,7 @@ func (evc *ethereumCarrier) Buckets(
 		previousIndex = big.NewInt(0)
 	}
 	buckets, err := evc.buckets(
-		&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(height)},
+		callOpts(height, blockHash),
 		previousIndex,
 		big.NewInt(int64(count)),
 	)
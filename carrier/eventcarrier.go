@@ -0,0 +1,528 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package carrier
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-election/contract"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+type bucketEventType int
+
+const (
+	bucketCreated bucketEventType = iota
+	bucketUpdated
+	bucketUnstaked
+	bucketWithdrawn
+)
+
+type (
+	bucketRecord struct {
+		canName        [12]byte
+		amount         *big.Int
+		stakeDuration  uint64 // in days
+		stakeStartTime int64
+		nonDecay       bool
+		owner          common.Address
+	}
+
+	bucketEvent struct {
+		eventType bucketEventType
+		height    uint64
+		index     uint64
+		record    bucketRecord
+	}
+
+	// bucketSet is the set of active buckets rebuilt from staking events
+	bucketSet map[uint64]*bucketRecord
+
+	// bucketCheckpoint is the bucket set of a height served, after applying the first numOfApplied events
+	bucketCheckpoint struct {
+		height       uint64
+		numOfApplied int
+		set          bucketSet
+	}
+
+	// logFilterer defines the source of the logs to rebuild the buckets from
+	logFilterer interface {
+		FilterLogs(ethereum.FilterQuery) ([]ethtypes.Log, error)
+	}
+
+	eventCarrier struct {
+		// Carrier serves all but the buckets
+		Carrier
+		logs                   logFilterer
+		stakingContractAddress common.Address
+		startHeight            uint64
+		blockRange             uint64
+		filterer               *contract.StakingFilterer
+		topics                 []common.Hash
+		// tokenFilterer parses the token transfers to staking contract, whose senders are the owners of the buckets
+		tokenFilterer *contract.IOTXFilterer
+		transferTopic common.Hash
+		mutex         sync.Mutex
+		// events are the staking events fetched up to fetchedHeight, sorted by height and log index. The events applied
+		// to the oldest checkpoint are dropped, and trimmed is set
+		events        []*bucketEvent
+		fetchedHeight uint64
+		trimmed       bool
+		// set is the bucket set after applying the first numOfApplied events, which is the state of setHeight
+		set          bucketSet
+		setHeight    uint64
+		numOfApplied int
+		// checkpoints are the bucket sets of the latest heights served, sorted by height, to rebuild a lower height
+		// from rather than from the start
+		checkpoints []*bucketCheckpoint
+	}
+)
+
+// maxBucketCheckpoints is the number of bucket sets kept for the heights served out of order
+const maxBucketCheckpoints = 32
+
+// NewEventVoteCarrier defines a carrier which rebuilds the buckets by replaying the events of staking contract,
+// rather than reading them from staking contract at each height
+func NewEventVoteCarrier(
+	confirmHeight uint64,
+	tickerDuration time.Duration,
+	clientURLs []string,
+	registerContractAddress common.Address,
+	stakingContractAddress common.Address,
+	quorum QuorumConfig,
+	stakingContractStartHeight uint64,
+	blockRange uint64,
+) (Carrier, error) {
+	base, err := NewEthereumVoteCarrier(
+		confirmHeight,
+		tickerDuration,
+		clientURLs,
+		registerContractAddress,
+		stakingContractAddress,
+		quorum,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := newEventCarrier(base, base.(*ethereumCarrier), stakingContractAddress, stakingContractStartHeight, blockRange)
+	if err != nil {
+		base.Close()
+		return nil, err
+	}
+	return ec, nil
+}
+
+func newEventCarrier(
+	base Carrier,
+	logs logFilterer,
+	stakingContractAddress common.Address,
+	stakingContractStartHeight uint64,
+	blockRange uint64,
+) (*eventCarrier, error) {
+	if blockRange == 0 {
+		return nil, errors.New("block range cannot be 0")
+	}
+	filterer, err := contract.NewStakingFilterer(stakingContractAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(contract.StakingABI))
+	if err != nil {
+		return nil, err
+	}
+	topics := []common.Hash{}
+	for _, name := range []string{"BucketCreated", "BucketUpdated", "BucketUnstake", "BucketWithdraw"} {
+		event, ok := parsed.Events[name]
+		if !ok {
+			return nil, errors.Errorf("event %s is not defined in staking contract", name)
+		}
+		topics = append(topics, event.ID)
+	}
+	tokenFilterer, err := contract.NewIOTXFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	tokenABI, err := abi.JSON(strings.NewReader(contract.IOTXABI))
+	if err != nil {
+		return nil, err
+	}
+	transfer, ok := tokenABI.Events["Transfer"]
+	if !ok {
+		return nil, errors.New("event Transfer is not defined in token contract")
+	}
+	var fetchedHeight uint64
+	if stakingContractStartHeight > 0 {
+		fetchedHeight = stakingContractStartHeight - 1
+	}
+	return &eventCarrier{
+		Carrier:                base,
+		logs:                   logs,
+		stakingContractAddress: stakingContractAddress,
+		startHeight:            stakingContractStartHeight,
+		blockRange:             blockRange,
+		filterer:               filterer,
+		topics:                 topics,
+		tokenFilterer:          tokenFilterer,
+		transferTopic:          transfer.ID,
+		fetchedHeight:          fetchedHeight,
+		set:                    bucketSet{},
+	}, nil
+}
+
+// Buckets returns the buckets on height rebuilt from the events. The logs of a range could not be pinned to a block
+// hash, so the caller is expected to check the hash of height after the read.
+func (ec *eventCarrier) Buckets(
+	height uint64,
+	_ common.Hash,
+	previousIndex *big.Int,
+	count uint8,
+) (*big.Int, []*types.Bucket, error) {
+	if previousIndex == nil || previousIndex.Cmp(big.NewInt(0)) < 0 {
+		previousIndex = big.NewInt(0)
+	}
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+	if height < ec.setHeight {
+		ec.restore(height)
+	}
+	if err := ec.fetchEvents(height); err != nil {
+		return nil, nil, err
+	}
+	for ; ec.numOfApplied < len(ec.events) && ec.events[ec.numOfApplied].height <= height; ec.numOfApplied++ {
+		if err := ec.set.apply(ec.events[ec.numOfApplied]); err != nil {
+			return nil, nil, err
+		}
+	}
+	ec.setHeight = height
+	ec.checkpoint()
+
+	return ec.set.buckets(previousIndex, count)
+}
+
+// Rollback drops the events above height and the bucket sets built from them, such that they are fetched again
+func (ec *eventCarrier) Rollback(height uint64) {
+	ec.Carrier.Rollback(height)
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+	if ec.fetchedHeight <= height {
+		return
+	}
+	i := sort.Search(len(ec.events), func(i int) bool {
+		return ec.events[i].height > height
+	})
+	ec.events = ec.events[:i]
+	ec.fetchedHeight = height
+	j := sort.Search(len(ec.checkpoints), func(j int) bool {
+		return ec.checkpoints[j].height > height
+	})
+	ec.checkpoints = ec.checkpoints[:j]
+	if ec.setHeight > height {
+		ec.restore(height)
+	}
+}
+
+// restore sets the bucket set to the latest checkpoint not above height, or to the empty one. If the events before the
+// oldest checkpoint are dropped, all the events are fetched again from the start.
+func (ec *eventCarrier) restore(height uint64) {
+	i := sort.Search(len(ec.checkpoints), func(i int) bool {
+		return ec.checkpoints[i].height > height
+	})
+	if i == 0 {
+		if ec.trimmed {
+			ec.events = nil
+			ec.checkpoints = nil
+			ec.fetchedHeight = 0
+			if ec.startHeight > 0 {
+				ec.fetchedHeight = ec.startHeight - 1
+			}
+			ec.trimmed = false
+		}
+		ec.set = bucketSet{}
+		ec.setHeight = 0
+		ec.numOfApplied = 0
+		return
+	}
+	cp := ec.checkpoints[i-1]
+	ec.set = cp.set.clone()
+	ec.setHeight = cp.height
+	ec.numOfApplied = cp.numOfApplied
+}
+
+// checkpoint keeps the bucket set of setHeight, and drops the oldest checkpoint and the events applied to the next one
+// if there are too many
+func (ec *eventCarrier) checkpoint() {
+	i := sort.Search(len(ec.checkpoints), func(i int) bool {
+		return ec.checkpoints[i].height >= ec.setHeight
+	})
+	if i < len(ec.checkpoints) && ec.checkpoints[i].height == ec.setHeight {
+		return
+	}
+	cp := &bucketCheckpoint{height: ec.setHeight, numOfApplied: ec.numOfApplied, set: ec.set.clone()}
+	ec.checkpoints = append(ec.checkpoints, nil)
+	copy(ec.checkpoints[i+1:], ec.checkpoints[i:])
+	ec.checkpoints[i] = cp
+	if len(ec.checkpoints) > maxBucketCheckpoints {
+		ec.checkpoints = ec.checkpoints[1:]
+		ec.trim()
+	}
+}
+
+// trim drops the events applied to the oldest checkpoint, and rebases the numbers of events applied
+func (ec *eventCarrier) trim() {
+	n := ec.checkpoints[0].numOfApplied
+	if n == 0 {
+		return
+	}
+	// the events are copied to release the dropped ones
+	ec.events = append([]*bucketEvent(nil), ec.events[n:]...)
+	for _, cp := range ec.checkpoints {
+		cp.numOfApplied -= n
+	}
+	ec.numOfApplied -= n
+	ec.trimmed = true
+}
+
+// fetchEvents fetches the staking events up to height
+func (ec *eventCarrier) fetchEvents(height uint64) error {
+	for ec.fetchedHeight < height {
+		from := ec.fetchedHeight + 1
+		to := from + ec.blockRange - 1
+		if to > height {
+			to = height
+		}
+		events, err := ec.fetchEventsInRange(from, to)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch staking events from %d to %d", from, to)
+		}
+		zap.L().Debug(
+			"fetch staking events",
+			zap.Uint64("from", from),
+			zap.Uint64("to", to),
+			zap.Int("number of events", len(events)),
+		)
+		ec.events = append(ec.events, events...)
+		ec.fetchedHeight = to
+	}
+	return nil
+}
+
+func (ec *eventCarrier) fetchEventsInRange(from uint64, to uint64) ([]*bucketEvent, error) {
+	logs, err := ec.logs.FilterLogs(ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{ec.stakingContractAddress},
+		Topics:    [][]common.Hash{ec.topics},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var transfers map[common.Hash][]ethtypes.Log
+	blockTimes := map[uint64]int64{}
+	events := make([]*bucketEvent, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+		event := &bucketEvent{height: log.BlockNumber}
+		switch log.Topics[0] {
+		case ec.topics[bucketCreated]:
+			created, err := ec.filterer.ParseBucketCreated(log)
+			if err != nil {
+				return nil, err
+			}
+			if transfers == nil {
+				if transfers, err = ec.transfers(from, to); err != nil {
+					return nil, err
+				}
+			}
+			owner, err := ec.creator(log, created.Amount, transfers[log.TxHash])
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get owner of bucket %d", created.BucketIndex)
+			}
+			startTime, ok := blockTimes[log.BlockNumber]
+			if !ok {
+				ts, err := ec.BlockTimestamp(log.BlockNumber)
+				if err != nil {
+					return nil, err
+				}
+				startTime = ts.Unix()
+				blockTimes[log.BlockNumber] = startTime
+			}
+			event.eventType = bucketCreated
+			event.index = created.BucketIndex.Uint64()
+			event.record = bucketRecord{
+				canName:        created.CanName,
+				amount:         created.Amount,
+				stakeDuration:  created.StakeDuration.Uint64(),
+				stakeStartTime: startTime,
+				nonDecay:       created.NonDecay,
+				owner:          owner,
+			}
+		case ec.topics[bucketUpdated]:
+			updated, err := ec.filterer.ParseBucketUpdated(log)
+			if err != nil {
+				return nil, err
+			}
+			event.eventType = bucketUpdated
+			event.index = updated.BucketIndex.Uint64()
+			event.record = bucketRecord{
+				canName:        updated.CanName,
+				stakeDuration:  updated.StakeDuration.Uint64(),
+				stakeStartTime: updated.StakeStartTime.Int64(),
+				nonDecay:       updated.NonDecay,
+				owner:          updated.BucketOwner,
+			}
+		case ec.topics[bucketUnstaked]:
+			unstaked, err := ec.filterer.ParseBucketUnstake(log)
+			if err != nil {
+				return nil, err
+			}
+			event.eventType = bucketUnstaked
+			event.index = unstaked.BucketIndex.Uint64()
+		case ec.topics[bucketWithdrawn]:
+			withdrawn, err := ec.filterer.ParseBucketWithdraw(log)
+			if err != nil {
+				return nil, err
+			}
+			event.eventType = bucketWithdrawn
+			event.index = withdrawn.BucketIndex.Uint64()
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// transfers returns the token transfers to staking contract from height from to height to, grouped by transaction
+func (ec *eventCarrier) transfers(from uint64, to uint64) (map[common.Hash][]ethtypes.Log, error) {
+	logs, err := ec.logs.FilterLogs(ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Topics: [][]common.Hash{
+			{ec.transferTopic},
+			nil,
+			{common.BytesToHash(ec.stakingContractAddress.Bytes())},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	transfers := map[common.Hash][]ethtypes.Log{}
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		transfers[log.TxHash] = append(transfers[log.TxHash], log)
+	}
+	return transfers, nil
+}
+
+// creator returns the owner of a newly created bucket, which is the sender of the token transfer to staking contract
+// right before the creation log. The transfer is made by staking contract from the caller of createBucket, whether
+// it is an account or a proxy contract, and no other log could be emitted in between.
+func (ec *eventCarrier) creator(created ethtypes.Log, amount *big.Int, transfers []ethtypes.Log) (common.Address, error) {
+	var last *ethtypes.Log
+	for i := range transfers {
+		if transfers[i].Index < created.Index && (last == nil || transfers[i].Index > last.Index) {
+			last = &transfers[i]
+		}
+	}
+	if last == nil {
+		return common.Address{}, errors.Errorf("no token transfer before log %d of tx %s", created.Index, created.TxHash.Hex())
+	}
+	transfer, err := ec.tokenFilterer.ParseTransfer(*last)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if transfer.Value.Cmp(amount) != 0 {
+		return common.Address{}, errors.Errorf("token transfer of %s mismatches staked amount %s", transfer.Value, amount)
+	}
+	return transfer.From, nil
+}
+
+func (set bucketSet) clone() bucketSet {
+	cloned := make(bucketSet, len(set))
+	for index, record := range set {
+		r := *record
+		cloned[index] = &r
+	}
+	return cloned
+}
+
+func (set bucketSet) apply(event *bucketEvent) error {
+	switch event.eventType {
+	case bucketCreated:
+		record := event.record
+		set[event.index] = &record
+	case bucketUpdated:
+		record, ok := set[event.index]
+		if !ok {
+			return errors.Errorf("bucket %d to update does not exist", event.index)
+		}
+		record.canName = event.record.canName
+		record.stakeDuration = event.record.stakeDuration
+		record.stakeStartTime = event.record.stakeStartTime
+		record.nonDecay = event.record.nonDecay
+		record.owner = event.record.owner
+	case bucketUnstaked, bucketWithdrawn:
+		delete(set, event.index)
+	default:
+		return errors.Errorf("unknown event type %d", event.eventType)
+	}
+	return nil
+}
+
+// buckets returns at most count buckets with index larger than previousIndex, in the same order as
+// GetActiveBuckets of staking contract
+func (set bucketSet) buckets(previousIndex *big.Int, count uint8) (*big.Int, []*types.Bucket, error) {
+	indexes := make([]uint64, 0, len(set))
+	for index := range set {
+		if new(big.Int).SetUint64(index).Cmp(previousIndex) > 0 {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+	if len(indexes) > int(count) {
+		indexes = indexes[:count]
+	}
+	bs := []*types.Bucket{}
+	for _, index := range indexes {
+		record := set[index]
+		v, err := types.NewBucket(
+			time.Unix(record.stakeStartTime, 0),
+			time.Duration(record.stakeDuration*24)*time.Hour,
+			record.amount,
+			record.owner.Bytes(),
+			record.canName[:],
+			!record.nonDecay,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		bs = append(bs, v)
+		previousIndex = new(big.Int).SetUint64(index)
+	}
+	return previousIndex, bs, nil
+}
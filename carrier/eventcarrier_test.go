@@ -0,0 +1,288 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package carrier
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/contract"
+)
+
+func TestBucketSet(t *testing.T) {
+	require := require.New(t)
+	owner1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	owner2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	can1 := [12]byte{'c', 'a', 'n', '1'}
+	can2 := [12]byte{'c', 'a', 'n', '2'}
+	events := []*bucketEvent{
+		{eventType: bucketCreated, height: 1, index: 1, record: bucketRecord{canName: can1, amount: big.NewInt(100), stakeDuration: 7, stakeStartTime: 1000, owner: owner1}},
+		{eventType: bucketCreated, height: 1, index: 2, record: bucketRecord{canName: can1, amount: big.NewInt(200), stakeDuration: 14, stakeStartTime: 1000, nonDecay: true, owner: owner1}},
+		{eventType: bucketCreated, height: 2, index: 3, record: bucketRecord{canName: can2, amount: big.NewInt(300), stakeDuration: 0, stakeStartTime: 2000, owner: owner2}},
+		{eventType: bucketUpdated, height: 3, index: 1, record: bucketRecord{canName: can2, stakeDuration: 28, stakeStartTime: 3000, owner: owner2}},
+		{eventType: bucketUnstaked, height: 4, index: 2},
+		{eventType: bucketWithdrawn, height: 5, index: 2},
+	}
+	set := bucketSet{}
+	for _, event := range events {
+		require.NoError(set.apply(event))
+	}
+	lastIndex, buckets, err := set.buckets(big.NewInt(0), 10)
+	require.NoError(err)
+	require.Equal(0, big.NewInt(3).Cmp(lastIndex))
+	require.Equal(2, len(buckets))
+	require.Equal(can2[:], buckets[0].Candidate())
+	require.Equal(owner2.Bytes(), buckets[0].Voter())
+	require.Equal(0, big.NewInt(100).Cmp(buckets[0].Amount()))
+	require.Equal(28*24*time.Hour, buckets[0].Duration())
+	require.Equal(int64(3000), buckets[0].StartTime().Unix())
+	require.True(buckets[0].Decay())
+	require.Equal(0, big.NewInt(300).Cmp(buckets[1].Amount()))
+
+	// paging
+	lastIndex, buckets, err = set.buckets(big.NewInt(0), 1)
+	require.NoError(err)
+	require.Equal(0, big.NewInt(1).Cmp(lastIndex))
+	require.Equal(1, len(buckets))
+	lastIndex, buckets, err = set.buckets(lastIndex, 1)
+	require.NoError(err)
+	require.Equal(0, big.NewInt(3).Cmp(lastIndex))
+	require.Equal(1, len(buckets))
+	lastIndex, buckets, err = set.buckets(lastIndex, 1)
+	require.NoError(err)
+	require.Equal(0, big.NewInt(3).Cmp(lastIndex))
+	require.Equal(0, len(buckets))
+
+	require.Error(set.apply(&bucketEvent{eventType: bucketUpdated, height: 6, index: 2}))
+}
+
+func TestEventCarrierCheckpoints(t *testing.T) {
+	require := require.New(t)
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	created := func(height uint64, index uint64) *bucketEvent {
+		return &bucketEvent{eventType: bucketCreated, height: height, index: index, record: bucketRecord{amount: big.NewInt(100), owner: owner}}
+	}
+	ec := &eventCarrier{
+		Carrier: &fakeCarrier{},
+		events: []*bucketEvent{
+			created(10, 1),
+			created(20, 2),
+			{eventType: bucketUpdated, height: 25, index: 1, record: bucketRecord{stakeDuration: 7, owner: owner}},
+			created(30, 3),
+		},
+		fetchedHeight: 30,
+		set:           bucketSet{},
+	}
+	count := func(height uint64) int {
+		_, buckets, err := ec.Buckets(height, common.Hash{}, big.NewInt(0), 10)
+		require.NoError(err)
+		return len(buckets)
+	}
+	require.Equal(3, count(30))
+	require.Equal(1, count(10))
+	require.Equal(2, count(20))
+	require.Equal(3, len(ec.checkpoints))
+	// the lower height is rebuilt from the checkpoint below it, whose set is not changed by the later events
+	_, buckets, err := ec.Buckets(20, common.Hash{}, big.NewInt(0), 1)
+	require.NoError(err)
+	require.Equal(time.Duration(0), buckets[0].Duration())
+	_, buckets, err = ec.Buckets(25, common.Hash{}, big.NewInt(0), 1)
+	require.NoError(err)
+	require.Equal(7*24*time.Hour, buckets[0].Duration())
+
+	// the events above the last matched height are dropped after a reorganization
+	ec.Rollback(20)
+	require.Equal(uint64(20), ec.fetchedHeight)
+	require.Equal(2, len(ec.events))
+	require.Equal(2, len(ec.checkpoints))
+	require.Equal(uint64(20), ec.setHeight)
+	ec.events = append(ec.events, created(22, 4))
+	ec.fetchedHeight = 30
+	_, buckets, err = ec.Buckets(30, common.Hash{}, big.NewInt(0), 10)
+	require.NoError(err)
+	require.Equal(3, len(buckets))
+	require.Equal(time.Duration(0), buckets[0].Duration())
+}
+
+type fakeLogFilterer struct {
+	queries []ethereum.FilterQuery
+}
+
+func (f *fakeLogFilterer) FilterLogs(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	f.queries = append(f.queries, query)
+	return nil, nil
+}
+
+func TestEventCarrierTrim(t *testing.T) {
+	require := require.New(t)
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	numOfEvents := uint64(maxBucketCheckpoints + 10)
+	events := []*bucketEvent{}
+	for i := uint64(1); i <= numOfEvents; i++ {
+		events = append(events, &bucketEvent{eventType: bucketCreated, height: i * 10, index: i, record: bucketRecord{amount: big.NewInt(100), owner: owner}})
+	}
+	logs := &fakeLogFilterer{}
+	ec := &eventCarrier{
+		Carrier:       &fakeCarrier{},
+		logs:          logs,
+		startHeight:   10,
+		blockRange:    1000,
+		events:        events,
+		fetchedHeight: numOfEvents * 10,
+		set:           bucketSet{},
+	}
+	count := func(height uint64) int {
+		_, buckets, err := ec.Buckets(height, common.Hash{}, big.NewInt(0), 255)
+		require.NoError(err)
+		return len(buckets)
+	}
+	for i := uint64(1); i <= numOfEvents; i++ {
+		require.Equal(int(i), count(i*10))
+	}
+	// the events are bounded by the checkpoints kept
+	require.Equal(maxBucketCheckpoints, len(ec.checkpoints))
+	require.Equal(maxBucketCheckpoints-1, len(ec.events))
+	require.True(ec.trimmed)
+	require.Equal(int(numOfEvents)-1, count(numOfEvents*10-10))
+	require.Equal(0, len(logs.queries))
+
+	// a height below the checkpoints kept is rebuilt from the events fetched again
+	require.Equal(0, count(10))
+	require.Equal(1, len(logs.queries))
+	require.Equal(0, logs.queries[0].FromBlock.Cmp(big.NewInt(10)))
+	require.False(ec.trimmed)
+	require.Equal(1, len(ec.checkpoints))
+}
+
+func TestEventCarrierCreator(t *testing.T) {
+	require := require.New(t)
+	tokenFilterer, err := contract.NewIOTXFilterer(common.Address{}, nil)
+	require.NoError(err)
+	ec := &eventCarrier{tokenFilterer: tokenFilterer}
+	staking := common.HexToAddress("0x87c9dbff0016af23f5b1ab9b8e072124ab729193")
+	transfer := func(from common.Address, amount int64, index uint) ethtypes.Log {
+		return ethtypes.Log{
+			Topics: []common.Hash{
+				crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(staking.Bytes()),
+			},
+			Data:  common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+			Index: index,
+		}
+	}
+	proxy := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	created := ethtypes.Log{Index: 5}
+	// the transfer right before the creation is made by staking contract from the caller
+	owner, err := ec.creator(created, big.NewInt(100), []ethtypes.Log{
+		transfer(other, 100, 1),
+		transfer(proxy, 100, 4),
+		transfer(other, 100, 6),
+	})
+	require.NoError(err)
+	require.Equal(proxy, owner)
+
+	_, err = ec.creator(created, big.NewInt(200), []ethtypes.Log{transfer(proxy, 100, 4)})
+	require.Error(err)
+	_, err = ec.creator(created, big.NewInt(100), []ethtypes.Log{transfer(proxy, 100, 6)})
+	require.Error(err)
+}
+
+// readBuckets reads all the buckets of height from a carrier page by page, and returns the last index and the
+// serialized buckets
+func readBuckets(require *require.Assertions, c Carrier, height uint64, count uint8) (*big.Int, []byte) {
+	index, data := big.NewInt(0), []byte{}
+	for {
+		nextIndex, buckets, err := c.Buckets(height, common.Hash{}, index, count)
+		require.NoError(err)
+		for _, bucket := range buckets {
+			b, err := bucket.Serialize()
+			require.NoError(err)
+			data = append(data, b...)
+		}
+		index = nextIndex
+		if len(buckets) < int(count) {
+			break
+		}
+	}
+	return index, data
+}
+
+// TestEventCarrierReplay checks the buckets rebuilt from the logs against the pages of buckets in a hand-made fixture,
+// for a history of creations by an account and a proxy, revotes, an owner change, an unstake and a withdrawal. The
+// parity with staking contract is checked by TestEventCarrierLiveParity.
+func TestEventCarrierReplay(t *testing.T) {
+	require := require.New(t)
+	replay, err := NewReplayCarrier(filepath.Join("testdata", "parity.jsonl"))
+	require.NoError(err)
+	stakingAddr := common.HexToAddress("0x87c9dbff0016af23f5b1ab9b8e072124ab729193")
+	ec, err := newEventCarrier(replay, replay.(logFilterer), stakingAddr, 100, 10)
+	require.NoError(err)
+	defer ec.Close()
+	// the heights are read out of order as in the parallel fetch
+	for _, height := range []uint64{110, 130, 120} {
+		expectedIndex, expected := readBuckets(require, replay, height, 2)
+		actualIndex, actual := readBuckets(require, ec, height, 2)
+		require.NotEmpty(expected)
+		require.Equal(0, expectedIndex.Cmp(actualIndex))
+		require.Equal(expected, actual)
+	}
+}
+
+// TestEventCarrierLiveParity checks the buckets rebuilt from the logs against the ones read from staking contract on
+// mainnet, if an ethereum api is provided, e.g.,
+// ELECTION_TEST_ETH_API="https://..." ELECTION_TEST_STAKING_START_HEIGHT=... go test ./carrier -run LiveParity
+// With ELECTION_TEST_PARITY_FIXTURE set, the responses are recorded into the fixture file as well.
+func TestEventCarrierLiveParity(t *testing.T) {
+	api := os.Getenv("ELECTION_TEST_ETH_API")
+	if api == "" {
+		t.Skip("ELECTION_TEST_ETH_API is not set")
+	}
+	require := require.New(t)
+	startHeight, err := strconv.ParseUint(os.Getenv("ELECTION_TEST_STAKING_START_HEIGHT"), 10, 64)
+	require.NoError(err, "ELECTION_TEST_STAKING_START_HEIGHT is required")
+	stakingAddr := common.HexToAddress("0x87c9dbff0016af23f5b1ab9b8e072124ab729193")
+	base, err := NewEthereumVoteCarrier(
+		0,
+		time.Minute,
+		[]string{api},
+		common.HexToAddress("0x95724986563028deb58f15c5fac19fa09304f32d"),
+		stakingAddr,
+		QuorumConfig{},
+	)
+	require.NoError(err)
+	if path := os.Getenv("ELECTION_TEST_PARITY_FIXTURE"); path != "" {
+		base, err = NewRecordingCarrier(base, path)
+		require.NoError(err)
+	}
+	ec, err := newEventCarrier(base, base.(logFilterer), stakingAddr, startHeight, 10000)
+	require.NoError(err)
+	defer ec.Close()
+	// the first heights of the election on mainnet, read out of order as in the parallel fetch
+	for _, height := range []uint64{7368630, 7368830, 7368730} {
+		expectedIndex, expected := readBuckets(require, base, height, 100)
+		actualIndex, actual := readBuckets(require, ec, height, 100)
+		require.NotEmpty(expected)
+		require.Equal(0, expectedIndex.Cmp(actualIndex))
+		require.Equal(expected, actual, "height %d", height)
+	}
+}
@@ -0,0 +1,487 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package carrier
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-election/types"
+)
+
+const (
+	methodTip              = "Tip"
+	methodBlockTimestamp   = "BlockTimestamp"
+	methodBlockHash        = "BlockHash"
+	methodHasStakingEvents = "HasStakingEvents"
+	methodRegistrations    = "Registrations"
+	methodBuckets          = "Buckets"
+	methodNewBlock         = "SubscribeNewBlock"
+	methodFilterLogs       = "FilterLogs"
+)
+
+type (
+	// fixture is one line of the fixture file, recording a request and its response
+	fixture struct {
+		Method    string   `json:"method"`
+		Height    uint64   `json:"height,omitempty"`
+		From      string   `json:"from,omitempty"`
+		To        string   `json:"to,omitempty"`
+		Index     string   `json:"index,omitempty"`
+		Count     uint8    `json:"count,omitempty"`
+		NextIndex string   `json:"nextIndex,omitempty"`
+		Timestamp int64    `json:"timestamp,omitempty"`
+		Hash      string   `json:"hash,omitempty"`
+		HasEvents bool     `json:"hasEvents,omitempty"`
+		Query     string   `json:"query,omitempty"`
+		Records   [][]byte `json:"records,omitempty"`
+		Error     string   `json:"error,omitempty"`
+	}
+
+	recordingCarrier struct {
+		carrier Carrier
+		file    *os.File
+		encoder *json.Encoder
+		mutex   sync.Mutex
+	}
+
+	replayCarrier struct {
+		fixtures map[string][]*fixture
+		cursors  map[string]int
+		mutex    sync.Mutex
+	}
+)
+
+func (f *fixture) key() string {
+	switch f.Method {
+	case methodBlockTimestamp, methodBlockHash:
+		return fmt.Sprintf("%s/%d", f.Method, f.Height)
+	case methodHasStakingEvents:
+		return fmt.Sprintf("%s/%s/%s", f.Method, f.From, f.To)
+	case methodRegistrations, methodBuckets:
+		return fmt.Sprintf("%s/%d/%s/%d", f.Method, f.Height, f.Index, f.Count)
+	case methodFilterLogs:
+		return fmt.Sprintf("%s/%s/%s/%s", f.Method, f.From, f.To, f.Query)
+	default:
+		return f.Method
+	}
+}
+
+func (f *fixture) err() error {
+	if f.Error == "" {
+		return nil
+	}
+	return errors.New(f.Error)
+}
+
+func bigIntString(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+	return i.String()
+}
+
+func parseBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid number %s", s)
+	}
+	return i, nil
+}
+
+// queryString returns the addresses and topics of a log query in a canonical form
+func queryString(query ethereum.FilterQuery) string {
+	parts := make([]string, 0, len(query.Topics)+1)
+	addrs := make([]string, 0, len(query.Addresses))
+	for _, addr := range query.Addresses {
+		addrs = append(addrs, addr.Hex())
+	}
+	parts = append(parts, strings.Join(addrs, ","))
+	for _, topics := range query.Topics {
+		hashes := make([]string, 0, len(topics))
+		for _, topic := range topics {
+			hashes = append(hashes, topic.Hex())
+		}
+		parts = append(parts, strings.Join(hashes, ","))
+	}
+	return strings.Join(parts, "|")
+}
+
+// NewRecordingCarrier wraps a carrier and writes all of its responses into a fixture file, which could be served
+// by a replay carrier
+func NewRecordingCarrier(carrier Carrier, path string) (Carrier, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingCarrier{
+		carrier: carrier,
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (rc *recordingCarrier) record(f *fixture, err error) {
+	if err != nil {
+		f.Error = err.Error()
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.encoder != nil {
+		// the recording is best-effort, and should never break the wrapped carrier
+		_ = rc.encoder.Encode(f)
+	}
+}
+
+func (rc *recordingCarrier) BlockTimestamp(height uint64) (time.Time, error) {
+	ts, err := rc.carrier.BlockTimestamp(height)
+	rc.record(&fixture{Method: methodBlockTimestamp, Height: height, Timestamp: ts.Unix()}, err)
+	return ts, err
+}
+
+func (rc *recordingCarrier) BlockHash(height uint64) (common.Hash, error) {
+	h, err := rc.carrier.BlockHash(height)
+	rc.record(&fixture{Method: methodBlockHash, Height: height, Hash: h.Hex()}, err)
+	return h, err
+}
+
+func (rc *recordingCarrier) SubscribeNewBlock(tipChan chan uint64, report chan error, unsubscribe chan bool) {
+	tips := make(chan uint64)
+	rc.carrier.SubscribeNewBlock(tips, report, unsubscribe)
+	go func() {
+		for {
+			select {
+			case <-unsubscribe:
+				return
+			case tip := <-tips:
+				rc.record(&fixture{Method: methodNewBlock, Height: tip}, nil)
+				select {
+				case <-unsubscribe:
+					return
+				case tipChan <- tip:
+				}
+			}
+		}
+	}()
+}
+
+func (rc *recordingCarrier) HasStakingEvents(from *big.Int, to *big.Int) bool {
+	retval := rc.carrier.HasStakingEvents(from, to)
+	rc.record(&fixture{
+		Method:    methodHasStakingEvents,
+		From:      bigIntString(from),
+		To:        bigIntString(to),
+		HasEvents: retval,
+	}, nil)
+	return retval
+}
+
+func (rc *recordingCarrier) Tip() (uint64, error) {
+	tip, err := rc.carrier.Tip()
+	rc.record(&fixture{Method: methodTip, Height: tip}, err)
+	return tip, err
+}
+
+func (rc *recordingCarrier) Registrations(
+	height uint64,
+	blockHash common.Hash,
+	startIndex *big.Int,
+	count uint8,
+) (*big.Int, []*types.Registration, error) {
+	nextIndex, regs, err := rc.carrier.Registrations(height, blockHash, startIndex, count)
+	f := &fixture{
+		Method:    methodRegistrations,
+		Height:    height,
+		Index:     bigIntString(startIndex),
+		Count:     count,
+		NextIndex: bigIntString(nextIndex),
+	}
+	for _, reg := range regs {
+		data, serr := reg.Serialize()
+		if serr != nil {
+			return nil, nil, serr
+		}
+		f.Records = append(f.Records, data)
+	}
+	rc.record(f, err)
+	return nextIndex, regs, err
+}
+
+func (rc *recordingCarrier) Buckets(
+	height uint64,
+	blockHash common.Hash,
+	previousIndex *big.Int,
+	count uint8,
+) (*big.Int, []*types.Bucket, error) {
+	nextIndex, buckets, err := rc.carrier.Buckets(height, blockHash, previousIndex, count)
+	f := &fixture{
+		Method:    methodBuckets,
+		Height:    height,
+		Index:     bigIntString(previousIndex),
+		Count:     count,
+		NextIndex: bigIntString(nextIndex),
+	}
+	for _, bucket := range buckets {
+		data, serr := bucket.Serialize()
+		if serr != nil {
+			return nil, nil, serr
+		}
+		f.Records = append(f.Records, data)
+	}
+	rc.record(f, err)
+	return nextIndex, buckets, err
+}
+
+// FilterLogs records the logs of the wrapped carrier, which is required to be a source of logs
+func (rc *recordingCarrier) FilterLogs(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	filterer, ok := rc.carrier.(logFilterer)
+	if !ok {
+		return nil, errors.New("carrier does not serve logs")
+	}
+	logs, err := filterer.FilterLogs(query)
+	f := &fixture{
+		Method: methodFilterLogs,
+		From:   bigIntString(query.FromBlock),
+		To:     bigIntString(query.ToBlock),
+		Query:  queryString(query),
+	}
+	for _, log := range logs {
+		data, serr := json.Marshal(log)
+		if serr != nil {
+			return nil, serr
+		}
+		f.Records = append(f.Records, data)
+	}
+	rc.record(f, err)
+	return logs, err
+}
+
+func (rc *recordingCarrier) Rollback(height uint64) {
+	rc.carrier.Rollback(height)
+}
+
+func (rc *recordingCarrier) Endpoints() []EndpointStatus {
+	return rc.carrier.Endpoints()
+}
+
+func (rc *recordingCarrier) Close() {
+	rc.carrier.Close()
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.file != nil {
+		rc.file.Close()
+		rc.file = nil
+		rc.encoder = nil
+	}
+}
+
+// NewReplayCarrier creates a carrier serving the responses in a fixture file written by a recording carrier.
+// The responses of the same request are served in the recorded order, and the last one is repeated afterwards.
+func NewReplayCarrier(path string) (Carrier, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return newReplayCarrier(file)
+}
+
+func newReplayCarrier(reader io.Reader) (*replayCarrier, error) {
+	fixtures := map[string][]*fixture{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		f := &fixture{}
+		if err := json.Unmarshal(scanner.Bytes(), f); err != nil {
+			return nil, errors.Wrap(err, "failed to parse fixture")
+		}
+		key := f.key()
+		fixtures[key] = append(fixtures[key], f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &replayCarrier{
+		fixtures: fixtures,
+		cursors:  map[string]int{},
+	}, nil
+}
+
+func (rc *replayCarrier) next(request *fixture) (*fixture, error) {
+	key := request.key()
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	fixtures, ok := rc.fixtures[key]
+	if !ok {
+		return nil, errors.Errorf("no fixture for %s", key)
+	}
+	cursor := rc.cursors[key]
+	if cursor >= len(fixtures) {
+		return fixtures[len(fixtures)-1], nil
+	}
+	rc.cursors[key] = cursor + 1
+	return fixtures[cursor], nil
+}
+
+func (rc *replayCarrier) BlockTimestamp(height uint64) (time.Time, error) {
+	f, err := rc.next(&fixture{Method: methodBlockTimestamp, Height: height})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(f.Timestamp, 0), f.err()
+}
+
+func (rc *replayCarrier) BlockHash(height uint64) (common.Hash, error) {
+	f, err := rc.next(&fixture{Method: methodBlockHash, Height: height})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(f.Hash), f.err()
+}
+
+func (rc *replayCarrier) SubscribeNewBlock(tipChan chan uint64, report chan error, unsubscribe chan bool) {
+	rc.mutex.Lock()
+	tips := rc.fixtures[methodNewBlock]
+	rc.mutex.Unlock()
+	go func() {
+		for _, f := range tips {
+			select {
+			case <-unsubscribe:
+				return
+			case tipChan <- f.Height:
+			}
+		}
+	}()
+}
+
+func (rc *replayCarrier) HasStakingEvents(from *big.Int, to *big.Int) bool {
+	f, err := rc.next(&fixture{Method: methodHasStakingEvents, From: bigIntString(from), To: bigIntString(to)})
+	if err != nil {
+		return true
+	}
+	return f.HasEvents
+}
+
+func (rc *replayCarrier) Tip() (uint64, error) {
+	f, err := rc.next(&fixture{Method: methodTip})
+	if err != nil {
+		return 0, err
+	}
+	return f.Height, f.err()
+}
+
+func (rc *replayCarrier) Registrations(
+	height uint64,
+	_ common.Hash,
+	startIndex *big.Int,
+	count uint8,
+) (*big.Int, []*types.Registration, error) {
+	f, err := rc.next(&fixture{Method: methodRegistrations, Height: height, Index: bigIntString(startIndex), Count: count})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := f.err(); err != nil {
+		return nil, nil, err
+	}
+	nextIndex, err := parseBigInt(f.NextIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	regs := make([]*types.Registration, 0, len(f.Records))
+	for _, data := range f.Records {
+		reg := &types.Registration{}
+		if err := reg.Deserialize(data); err != nil {
+			return nil, nil, err
+		}
+		regs = append(regs, reg)
+	}
+	return nextIndex, regs, nil
+}
+
+func (rc *replayCarrier) Buckets(
+	height uint64,
+	_ common.Hash,
+	previousIndex *big.Int,
+	count uint8,
+) (*big.Int, []*types.Bucket, error) {
+	f, err := rc.next(&fixture{Method: methodBuckets, Height: height, Index: bigIntString(previousIndex), Count: count})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := f.err(); err != nil {
+		return nil, nil, err
+	}
+	nextIndex, err := parseBigInt(f.NextIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	buckets := make([]*types.Bucket, 0, len(f.Records))
+	for _, data := range f.Records {
+		bucket := &types.Bucket{}
+		if err := bucket.Deserialize(data); err != nil {
+			return nil, nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	return nextIndex, buckets, nil
+}
+
+func (rc *replayCarrier) FilterLogs(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	f, err := rc.next(&fixture{
+		Method: methodFilterLogs,
+		From:   bigIntString(query.FromBlock),
+		To:     bigIntString(query.ToBlock),
+		Query:  queryString(query),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := f.err(); err != nil {
+		return nil, err
+	}
+	logs := make([]ethtypes.Log, 0, len(f.Records))
+	for _, data := range f.Records {
+		var log ethtypes.Log
+		if err := json.Unmarshal(data, &log); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// Rollback does nothing since the responses are replayed from file
+func (rc *replayCarrier) Rollback(uint64) {}
+
+// Endpoints returns no endpoint since the data are replayed from file
+func (rc *replayCarrier) Endpoints() []EndpointStatus {
+	return []EndpointStatus{}
+}
+
+func (rc *replayCarrier) Close() {}
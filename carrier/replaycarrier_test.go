@@ -0,0 +1,151 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package carrier
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/types"
+)
+
+type fakeCarrier struct {
+	tips []uint64
+}
+
+func (fc *fakeCarrier) BlockTimestamp(height uint64) (time.Time, error) {
+	return time.Unix(int64(1550000000+height*15), 0), nil
+}
+
+func (fc *fakeCarrier) BlockHash(height uint64) (common.Hash, error) {
+	return crypto.Keccak256Hash(new(big.Int).SetUint64(height).Bytes()), nil
+}
+
+func (fc *fakeCarrier) SubscribeNewBlock(tipChan chan uint64, _ chan error, unsubscribe chan bool) {
+	go func() {
+		for _, tip := range fc.tips {
+			select {
+			case <-unsubscribe:
+				return
+			case tipChan <- tip:
+			}
+		}
+	}()
+}
+
+func (fc *fakeCarrier) HasStakingEvents(from *big.Int, _ *big.Int) bool {
+	return from.Uint64()%2 == 0
+}
+
+func (fc *fakeCarrier) Tip() (uint64, error) {
+	return 100, nil
+}
+
+func (fc *fakeCarrier) Registrations(height uint64, _ common.Hash, startIndex *big.Int, count uint8) (*big.Int, []*types.Registration, error) {
+	if height == 0 {
+		return nil, nil, errors.New("invalid height")
+	}
+	regs := []*types.Registration{}
+	for i := startIndex.Uint64(); i < startIndex.Uint64()+uint64(count) && i <= 3; i++ {
+		regs = append(regs, types.NewRegistration(
+			[]byte{byte(i)},
+			[]byte{byte(i), 1},
+			[]byte{byte(i), 2},
+			[]byte{byte(i), 3},
+			height,
+		))
+	}
+	return new(big.Int).Add(startIndex, big.NewInt(int64(len(regs)))), regs, nil
+}
+
+func (fc *fakeCarrier) Buckets(height uint64, _ common.Hash, previousIndex *big.Int, count uint8) (*big.Int, []*types.Bucket, error) {
+	buckets := []*types.Bucket{}
+	index := previousIndex.Uint64()
+	for i := index + 1; i <= index+uint64(count) && i <= 5; i++ {
+		bucket, err := types.NewBucket(
+			time.Unix(1540000000, 0),
+			time.Duration(i*24)*time.Hour,
+			new(big.Int).SetUint64(height*i),
+			[]byte{byte(i)},
+			[]byte{byte(i%3 + 1)},
+			i%2 == 0,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		buckets = append(buckets, bucket)
+		index = i
+	}
+	return new(big.Int).SetUint64(index), buckets, nil
+}
+
+func (fc *fakeCarrier) Rollback(uint64) {}
+
+func (fc *fakeCarrier) Endpoints() []EndpointStatus { return nil }
+
+func (fc *fakeCarrier) Close() {}
+
+func TestRecordAndReplay(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "fixture.jsonl")
+	recorder, err := NewRecordingCarrier(&fakeCarrier{tips: []uint64{110, 120}}, path)
+	require.NoError(err)
+	calls := func(c Carrier) []interface{} {
+		retval := []interface{}{}
+		tip, err := c.Tip()
+		retval = append(retval, tip, err)
+		ts, err := c.BlockTimestamp(10)
+		retval = append(retval, ts.Unix(), err)
+		h, err := c.BlockHash(10)
+		retval = append(retval, h, err)
+		retval = append(retval, c.HasStakingEvents(big.NewInt(2), big.NewInt(10)))
+		retval = append(retval, c.HasStakingEvents(big.NewInt(3), big.NewInt(10)))
+		for _, height := range []uint64{0, 10} {
+			nextIndex, regs, err := c.Registrations(height, common.Hash{}, big.NewInt(1), 2)
+			retval = append(retval, nextIndex, err != nil)
+			for _, reg := range regs {
+				data, err := reg.Serialize()
+				require.NoError(err)
+				retval = append(retval, data)
+			}
+		}
+		nextIndex, buckets, err := c.Buckets(10, common.Hash{}, big.NewInt(2), 2)
+		retval = append(retval, nextIndex, err)
+		for _, bucket := range buckets {
+			data, err := bucket.Serialize()
+			require.NoError(err)
+			retval = append(retval, data)
+		}
+		tipChan := make(chan uint64)
+		unsubscribe := make(chan bool)
+		c.SubscribeNewBlock(tipChan, make(chan error), unsubscribe)
+		retval = append(retval, <-tipChan, <-tipChan)
+		close(unsubscribe)
+		return retval
+	}
+	expected := calls(recorder)
+	recorder.Close()
+
+	replayer, err := NewReplayCarrier(path)
+	require.NoError(err)
+	defer replayer.Close()
+	require.Equal(expected, calls(replayer))
+
+	_, err = replayer.BlockTimestamp(11)
+	require.Error(err)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package carrier
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEthService struct {
+	mutex      sync.Mutex
+	head       uint64
+	notify     func(*ethtypes.Header)
+	subscribed chan bool
+}
+
+func (s *fakeEthService) header(height uint64) *ethtypes.Header {
+	return &ethtypes.Header{
+		Number:     new(big.Int).SetUint64(height),
+		Difficulty: big.NewInt(0),
+		Time:       height,
+	}
+}
+
+func (s *fakeEthService) GetBlockByNumber(_ context.Context, _ rpc.BlockNumber, _ bool) (*ethtypes.Header, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.header(s.head), nil
+}
+
+func (s *fakeEthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+	s.mutex.Lock()
+	s.notify = func(header *ethtypes.Header) {
+		notifier.Notify(sub.ID, header)
+	}
+	s.mutex.Unlock()
+	s.subscribed <- true
+	return sub, nil
+}
+
+func (s *fakeEthService) produce(height uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.head = height
+	if s.notify != nil {
+		s.notify(s.header(height))
+	}
+}
+
+func TestWebsocketURLs(t *testing.T) {
+	require := require.New(t)
+	require.Equal(0, len(websocketURLs([]string{"https://a", "http://b"})))
+	require.Equal(
+		[]string{"ws://b", "wss://d"},
+		websocketURLs([]string{"https://a", "ws://b", "http://c", "wss://d"}),
+	)
+}
+
+func TestSubscribeNewHead(t *testing.T) {
+	require := require.New(t)
+	service := &fakeEthService{head: 100, subscribed: make(chan bool, 10)}
+	var lock sync.Mutex
+	var server *rpc.Server
+	restart := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if server != nil {
+			server.Stop()
+		}
+		server = rpc.NewServer()
+		require.NoError(server.RegisterName("eth", service))
+	}
+	restart()
+	defer func() {
+		lock.Lock()
+		defer lock.Unlock()
+		server.Stop()
+	}()
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		handler := server.WebsocketHandler([]string{"*"})
+		lock.Unlock()
+		handler.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+	url := "ws://" + strings.TrimPrefix(httpServer.URL, "http://")
+
+	carrier, err := NewEthereumVoteCarrier(
+		12,
+		time.Hour,
+		[]string{url},
+		common.Address{},
+		common.Address{},
+		QuorumConfig{},
+	)
+	require.NoError(err)
+	defer carrier.Close()
+	tipChan := make(chan uint64)
+	report := make(chan error, 10)
+	unsubscribe := make(chan bool)
+	defer close(unsubscribe)
+	carrier.SubscribeNewBlock(tipChan, report, unsubscribe)
+	receive := func() uint64 {
+		select {
+		case tip := <-tipChan:
+			return tip
+		case <-time.After(10 * time.Second):
+			require.FailNow("timeout")
+		}
+		return 0
+	}
+
+	<-service.subscribed
+	require.Equal(uint64(88), receive())
+	service.produce(101)
+	require.Equal(uint64(89), receive())
+	service.produce(101)
+	service.produce(102)
+	require.Equal(uint64(90), receive())
+
+	// reconnect and backfill the heights produced while disconnected
+	service.mutex.Lock()
+	service.notify = nil
+	service.head = 110
+	service.mutex.Unlock()
+	restart()
+	require.Error(<-report)
+	<-service.subscribed
+	require.Equal(uint64(98), receive())
+	service.produce(111)
+	require.Equal(uint64(99), receive())
+}
@@ -21,37 +21,45 @@ import (
 	"github.com/pkg/errors"
 	_ "modernc.org/sqlite"
 
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-election/db"
 	"github.com/iotexproject/iotex-election/types"
 )
 
 type BucketArchive struct {
-	startHeight         uint64
-	interval            uint64
-	db                  *sql.DB
-	bucketTableOperator Operator
-	timeTableOperator   Operator
+	startHeight            uint64
+	interval               uint64
+	db                     *sql.DB
+	bucketTableOperator    Operator
+	timeTableOperator      *TimeTableOperator
+	blockHashTableOperator *HashTableOperator
 
 	// Put (native) polls are synchronized to get rid of the risk of reading uncommitted changes from other tx on the
 	// same connection.
 	mutex sync.Mutex
 }
 
-// NewBucketArchive creates a new archive of bucket
-func NewBucketArchive(dbPath string, numOfRetries uint8, startHeight uint64, interval uint64) (*BucketArchive, error) {
-	sqlDB, err := sql.Open("sqlite", dbPath)
+// NewBucketArchive creates a new archive of bucket in the sql db defined in cfg
+func NewBucketArchive(cfg db.Config, startHeight uint64, interval uint64) (*BucketArchive, error) {
+	driverName, err := ParseDriverType(cfg.Driver)
 	if err != nil {
 		return nil, err
 	}
-	bucketTableOperator, err := NewDeltaBucketTableOperator("buckets", SQLITE)
+	sqlDB, err := openDB(driverName, cfg)
+	if err != nil {
+		return nil, err
+	}
+	bucketTableOperator, err := NewDeltaBucketTableOperator("buckets", driverName)
 	if err != nil {
 		return nil, err
 	}
 	return &BucketArchive{
-		db:                  sqlDB,
-		startHeight:         startHeight,
-		interval:            interval,
-		timeTableOperator:   NewTimeTableOperator("mint_time", SQLITE),
-		bucketTableOperator: bucketTableOperator,
+		db:                     sqlDB,
+		startHeight:            startHeight,
+		interval:               interval,
+		timeTableOperator:      NewTimeTableOperator("mint_time", driverName),
+		bucketTableOperator:    bucketTableOperator,
+		blockHashTableOperator: NewHashTableOperator("block_hash", driverName),
 	}, nil
 }
 
@@ -71,6 +79,10 @@ func (arch *BucketArchive) MintTime(height uint64) (time.Time, error) {
 	return mintTime, nil
 }
 
+func (arch *BucketArchive) HeightBefore(ts time.Time) (uint64, error) {
+	return arch.timeTableOperator.HeightBefore(ts, arch.db, nil)
+}
+
 func (arch *BucketArchive) Buckets(height uint64) ([]*types.Bucket, error) {
 	value, err := arch.bucketTableOperator.Get(height, arch.db, nil)
 	if err != nil {
@@ -83,7 +95,21 @@ func (arch *BucketArchive) Buckets(height uint64) ([]*types.Bucket, error) {
 	return records, nil
 }
 
-func (arch *BucketArchive) PutDelta(height uint64, ts time.Time, updatedBuckets []*pyggBucket) (err error) {
+// BlockHash returns the hash of the block which the delta of height ends at
+func (arch *BucketArchive) BlockHash(height uint64) (hash.Hash256, error) {
+	value, err := arch.blockHashTableOperator.Get(height, arch.db, nil)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	blockHash, ok := value.(hash.Hash256)
+	if !ok {
+		return hash.ZeroHash256, errors.Errorf("Unexpected type %s", reflect.TypeOf(value))
+	}
+	return blockHash, nil
+}
+
+// PutDelta puts the buckets updated in the blocks ending at height, with the mint time and the hash of the last block
+func (arch *BucketArchive) PutDelta(height uint64, ts time.Time, blockHash hash.Hash256, updatedBuckets []*pyggBucket) (err error) {
 	arch.mutex.Lock()
 	defer arch.mutex.Unlock()
 
@@ -98,6 +124,33 @@ func (arch *BucketArchive) PutDelta(height uint64, ts time.Time, updatedBuckets
 	if err := arch.bucketTableOperator.Put(height, updatedBuckets, tx); err != nil {
 		return err
 	}
+	if blockHash != hash.ZeroHash256 {
+		if err := arch.blockHashTableOperator.Put(height, blockHash, tx); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Rollback deletes the deltas at and above height
+func (arch *BucketArchive) Rollback(height uint64) (err error) {
+	arch.mutex.Lock()
+	defer arch.mutex.Unlock()
+
+	tx, err := arch.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := arch.bucketTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.timeTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.blockHashTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -114,6 +167,9 @@ func (arch *BucketArchive) Start(ctx context.Context) (err error) {
 	if err = arch.bucketTableOperator.CreateTables(tx); err != nil {
 		return err
 	}
+	if err = arch.blockHashTableOperator.CreateTables(tx); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -0,0 +1,194 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-election/types"
+)
+
+// MaxCandidateHistoryPoints is the max number of points returned by CandidateHistory, and the max number of heights
+// covered by VoterHistory
+const MaxCandidateHistoryPoints = 1000
+
+type (
+	// CandidatePoint defines the stats of a candidate at a height
+	CandidatePoint struct {
+		Height   uint64
+		MintTime time.Time
+		// Rank starts from 1
+		Rank              int
+		Score             *big.Int
+		SelfStakingTokens *big.Int
+		VoterCount        int
+	}
+
+	// candidateSummary is the compact stats of the candidates at a height, which is cached instead of the result
+	candidateSummary struct {
+		mintTime time.Time
+		points   map[string]*CandidatePoint
+	}
+)
+
+func newCandidateSummary(height uint64, result *types.ElectionResult) *candidateSummary {
+	summary := &candidateSummary{
+		mintTime: result.MintTime(),
+		points:   map[string]*CandidatePoint{},
+	}
+	for i, delegate := range result.Delegates() {
+		voters := map[string]bool{}
+		for _, vote := range result.VotesByDelegate(delegate.Name()) {
+			voters[hex.EncodeToString(vote.Voter())] = true
+		}
+		summary.points[hex.EncodeToString(delegate.Name())] = &CandidatePoint{
+			Height:            height,
+			MintTime:          result.MintTime(),
+			Rank:              i + 1,
+			Score:             new(big.Int).Set(delegate.Score()),
+			SelfStakingTokens: new(big.Int).Set(delegate.SelfStakingTokens()),
+			VoterCount:        len(voters),
+		}
+	}
+	return summary
+}
+
+// CandidateHistory returns the stats of a candidate at every step heights in [fromHeight, toHeight], skipping the
+// heights at which the candidate is not qualified. A step of 0 stands for the gravity chain height interval. The stats
+// of each height are computed once and cached, such that a series could be extended without recalculation.
+func (ec *committee) CandidateHistory(name []byte, fromHeight uint64, toHeight uint64, step uint64) ([]*CandidatePoint, error) {
+	ec.mutex.RLock()
+	heights, err := historyHeights(ec.startHeight, ec.interval, ec.latestHeightInArchive(), fromHeight, toHeight, step)
+	ec.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return candidatePoints(name, heights, func(height uint64) (*candidateSummary, error) {
+		return cachedCandidateSummary(ec.historyCache, ec.cache, ec.mutex.RLocker(), &ec.rollbacks, height, ec.loadResult)
+	})
+}
+
+// historyHeights returns the heights at every step in [fromHeight, toHeight], which are aligned with the start height
+// and the interval, and no higher than the tip height. A step of 0 stands for the interval.
+func historyHeights(
+	startHeight uint64,
+	interval uint64,
+	tipHeight uint64,
+	fromHeight uint64,
+	toHeight uint64,
+	step uint64,
+) ([]uint64, error) {
+	if step == 0 {
+		step = interval
+	}
+	if step%interval != 0 {
+		return nil, errors.Errorf("step %d is not a multiple of interval %d", step, interval)
+	}
+	if fromHeight < startHeight {
+		fromHeight = startHeight
+	}
+	if offset := (fromHeight - startHeight) % interval; offset != 0 {
+		fromHeight += interval - offset
+	}
+	if toHeight > tipHeight {
+		toHeight = tipHeight
+	}
+	if fromHeight > toHeight {
+		return []uint64{}, nil
+	}
+	if (toHeight-fromHeight)/step >= MaxCandidateHistoryPoints {
+		return nil, errors.Errorf("more than %d points in range [%d, %d]", MaxCandidateHistoryPoints, fromHeight, toHeight)
+	}
+	heights := make([]uint64, 0, (toHeight-fromHeight)/step+1)
+	for height := fromHeight; height <= toHeight; height += step {
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
+func candidatePoints(
+	name []byte,
+	heights []uint64,
+	summaryByHeight func(uint64) (*candidateSummary, error),
+) ([]*CandidatePoint, error) {
+	key := hex.EncodeToString(name)
+	points := []*CandidatePoint{}
+	for _, height := range heights {
+		summary, err := summaryByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		if point, ok := summary.points[key]; ok {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+// cachedCandidateSummary returns the candidate summary of height from the history cache, or computes it from the
+// result in the result cache or calculated. Only the data of height is read with the lock held, and the calculation
+// is run after the lock is released. The summary is not cached if a rollback, counted in rollbacks, happens meanwhile.
+func cachedCandidateSummary(
+	historyCache *lru.Cache,
+	resultCache *lru.Cache,
+	lock sync.Locker,
+	rollbacks *uint64,
+	height uint64,
+	loadResult func(uint64) (func() (*types.ElectionResult, error), error),
+) (*candidateSummary, error) {
+	value, ok := historyCache.Get(height)
+	reportCacheLookup("candidate_history", ok)
+	if ok {
+		if summary, as := value.(*candidateSummary); as {
+			return summary, nil
+		}
+		return nil, errors.New("lru cache type assertion has error")
+	}
+	var result *types.ElectionResult
+	var calculate func() (*types.ElectionResult, error)
+	lock.Lock()
+	generation := *rollbacks
+	value, ok = resultCache.Get(height)
+	if !ok {
+		var err error
+		if calculate, err = loadResult(height); err != nil {
+			lock.Unlock()
+			return nil, err
+		}
+	}
+	lock.Unlock()
+	if ok {
+		var as bool
+		if result, as = value.(*types.ElectionResult); !as {
+			return nil, errors.New("lru cache type assertion has error")
+		}
+	} else {
+		// the result is not cached to leave the result cache for the recent heights
+		var err error
+		if result, err = calculate(); err != nil {
+			return nil, err
+		}
+	}
+	summary := newCandidateSummary(height, result)
+	lock.Lock()
+	defer lock.Unlock()
+	// a summary computed before a rollback is not cached, as the history cache is purged on it
+	if *rollbacks == generation {
+		historyCache.Add(height, summary)
+	}
+	return summary, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/carrier"
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+func TestCandidateHistory(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	replayCarrier, err := carrier.NewReplayCarrier(filepath.Join("testdata", "replay.jsonl"))
+	require.NoError(err)
+	arch, err := NewArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	c, err := NewCommitteeWithCarrier(arch, replayCarrier, Config{
+		NumOfRetries:               3,
+		GravityChainHeightInterval: 10,
+		GravityChainStartHeight:    100,
+		PaginationSize:             2,
+		VoteThreshold:              "0",
+		ScoreThreshold:             "0",
+		SelfStakingThreshold:       "0",
+		CacheSize:                  1,
+	})
+	require.NoError(err)
+	require.NoError(c.Start(ctx))
+	defer func() {
+		require.NoError(c.Stop(ctx))
+	}()
+	deadline := time.Now().Add(10 * time.Second)
+	for c.LatestHeight() < 140 {
+		require.True(time.Now().Before(deadline), "timeout")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	name := append(make([]byte, 3), []byte("delegate1")...)
+	points, err := c.CandidateHistory(name, 95, 1000, 20)
+	require.NoError(err)
+	require.Equal(3, len(points))
+	for i, expected := range []struct {
+		height uint64
+		rank   int
+		score  string
+	}{
+		{100, 2, "32771856207484561673838"},
+		{120, 1, "50505060172438719003906"},
+		{140, 3, "50616769168941179040112"},
+	} {
+		require.Equal(expected.height, points[i].Height)
+		require.Equal(expected.rank, points[i].Rank)
+		require.Equal(expected.score, points[i].Score.String())
+		require.Equal(int64(1550000000+expected.height*15), points[i].MintTime.Unix())
+		result, err := c.ResultByHeight(expected.height)
+		require.NoError(err)
+		delegate := result.DelegateByName(name)
+		require.Equal(delegate.SelfStakingTokens(), points[i].SelfStakingTokens)
+		voters := map[string]bool{}
+		for _, vote := range result.VotesByDelegate(name) {
+			voters[string(vote.Voter())] = true
+		}
+		require.Equal(len(voters), points[i].VoterCount)
+	}
+	require.Equal(3, c.(*committee).historyCache.Len())
+
+	// the cached points are reused
+	points, err = c.CandidateHistory(name, 100, 140, 0)
+	require.NoError(err)
+	require.Equal(5, len(points))
+	require.Equal(5, c.(*committee).historyCache.Len())
+
+	points, err = c.CandidateHistory([]byte("unknown"), 100, 140, 0)
+	require.NoError(err)
+	require.Equal(0, len(points))
+	_, err = c.CandidateHistory(name, 100, 140, 15)
+	require.Error(err)
+}
+
+func TestHistoryHeights(t *testing.T) {
+	require := require.New(t)
+	heights, err := historyHeights(100, 10, 100+(MaxCandidateHistoryPoints-1)*10, 0, math.MaxUint64, 0)
+	require.NoError(err)
+	require.Equal(MaxCandidateHistoryPoints, len(heights))
+	require.Equal(uint64(100), heights[0])
+	_, err = historyHeights(100, 10, 100+MaxCandidateHistoryPoints*10, 0, math.MaxUint64, 0)
+	require.Error(err)
+	heights, err = historyHeights(100, 10, 100+MaxCandidateHistoryPoints*10, 105, 125, 0)
+	require.NoError(err)
+	require.Equal([]uint64{110, 120}, heights)
+	heights, err = historyHeights(100, 10, 140, 150, 200, 0)
+	require.NoError(err)
+	require.Equal(0, len(heights))
+}
+
+func TestCachedCandidateSummaryLock(t *testing.T) {
+	require := require.New(t)
+	historyCache, err := lru.New(10)
+	require.NoError(err)
+	resultCache, err := lru.New(10)
+	require.NoError(err)
+	var mutex sync.RWMutex
+	var rollbacks uint64
+	summary, err := cachedCandidateSummary(historyCache, resultCache, mutex.RLocker(), &rollbacks, 100, func(uint64) (func() (*types.ElectionResult, error), error) {
+		// the data is read with the lock held
+		require.False(mutex.TryLock())
+		return func() (*types.ElectionResult, error) {
+			// the calculation does not block the writers
+			require.True(mutex.TryLock())
+			mutex.Unlock()
+			return types.NewElectionResultForTest(time.Unix(1600000000, 0)), nil
+		}, nil
+	})
+	require.NoError(err)
+	require.Equal(2, len(summary.points))
+	require.Equal(1, historyCache.Len())
+	require.Equal(0, resultCache.Len())
+
+	// the summary calculated across a rollback is returned but not cached
+	summary, err = cachedCandidateSummary(historyCache, resultCache, mutex.RLocker(), &rollbacks, 110, func(uint64) (func() (*types.ElectionResult, error), error) {
+		return func() (*types.ElectionResult, error) {
+			mutex.Lock()
+			historyCache.Purge()
+			rollbacks++
+			mutex.Unlock()
+			return types.NewElectionResultForTest(time.Unix(1600000000, 0)), nil
+		}, nil
+	})
+	require.NoError(err)
+	require.Equal(2, len(summary.points))
+	require.Equal(0, historyCache.Len())
+}
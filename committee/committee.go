@@ -12,8 +12,6 @@ package committee
 
 import (
 	"context"
-	"encoding/hex"
-	"math"
 	"math/big"
 	"sort"
 	"strconv"
@@ -31,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	_ "modernc.org/sqlite"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-election/carrier"
 	"github.com/iotexproject/iotex-election/db"
 	"github.com/iotexproject/iotex-election/types"
@@ -41,21 +40,34 @@ type CalcGravityChainHeight func(uint64) (uint64, error)
 
 // Config defines the config of the committee
 type Config struct {
-	NumOfRetries               uint8    `yaml:"numOfRetries"`
-	GravityChainAPIs           []string `yaml:"gravityChainAPIs"`
-	GravityChainHeightInterval uint64   `yaml:"gravityChainHeightInterval"`
-	GravityChainStartHeight    uint64   `yaml:"gravityChainStartHeight"`
-	GravityChainCeilingHeight  uint64   `yaml:"gravityChainCeilingHeight"`
-	RegisterContractAddress    string   `yaml:"registerContractAddress"`
-	StakingContractAddress     string   `yaml:"stakingContractAddress"`
-	PaginationSize             uint8    `yaml:"paginationSize"`
-	VoteThreshold              string   `yaml:"voteThreshold"`
-	ScoreThreshold             string   `yaml:"scoreThreshold"`
-	SelfStakingThreshold       string   `yaml:"selfStakingThreshold"`
-	CacheSize                  uint32   `yaml:"cacheSize"`
-	NumOfFetchInParallel       uint8    `yaml:"numOfFetchInParallel"`
-	SkipManifiedCandidate      bool     `yaml:"skipManifiedCandidate"`
-	GravityChainBatchSize      uint64   `yaml:"gravityChainBatchSize"`
+	NumOfRetries                uint8                             `yaml:"numOfRetries"`
+	GravityChainAPIs            []string                          `yaml:"gravityChainAPIs"`
+	GravityChainHeightInterval  uint64                            `yaml:"gravityChainHeightInterval"`
+	GravityChainStartHeight     uint64                            `yaml:"gravityChainStartHeight"`
+	GravityChainCeilingHeight   uint64                            `yaml:"gravityChainCeilingHeight"`
+	RegisterContractAddress     string                            `yaml:"registerContractAddress"`
+	StakingContractAddress      string                            `yaml:"stakingContractAddress"`
+	PaginationSize              uint8                             `yaml:"paginationSize"`
+	VoteThreshold               string                            `yaml:"voteThreshold"`
+	ScoreThreshold              string                            `yaml:"scoreThreshold"`
+	SelfStakingThreshold        string                            `yaml:"selfStakingThreshold"`
+	CacheSize                   uint32                            `yaml:"cacheSize"`
+	NumOfFetchInParallel        uint8                             `yaml:"numOfFetchInParallel"`
+	SkipManifiedCandidate       bool                              `yaml:"skipManifiedCandidate"`
+	GravityChainBatchSize       uint64                            `yaml:"gravityChainBatchSize"`
+	GravityChainQuorumSize      int                               `yaml:"gravityChainQuorumSize"`
+	GravityChainQuorumThreshold int                               `yaml:"gravityChainQuorumThreshold"`
+	StakingContractStartHeight  uint64                            `yaml:"stakingContractStartHeight"`
+	FetchBucketsFromEvents      bool                              `yaml:"fetchBucketsFromEvents"`
+	WeightingPolicies           []types.WeightingPolicyActivation `yaml:"weightingPolicies"`
+	ConfirmHeight               uint64                            `yaml:"confirmHeight"`
+	ReorgCheckDepth             uint64                            `yaml:"reorgCheckDepth"`
+	ScoreOverridesPath          string                            `yaml:"scoreOverridesPath"`
+	HistoryCacheSize            uint32                            `yaml:"historyCacheSize"`
+	Health                      HealthConfig                      `yaml:"health"`
+	// RecordFixturePath is the file the responses of the carrier are recorded into, which could be replayed by
+	// carrier.NewReplayCarrier. Nothing is recorded if it is empty.
+	RecordFixturePath string `yaml:"recordFixturePath"`
 }
 
 // STATUS represents the status of committee
@@ -74,27 +86,54 @@ const (
 const EthHardForkHeight = 8581700
 
 type (
-	// Committee defines an interface of an election committee
-	// It could be considered as a light state db of gravity chain, that
-	Committee interface {
+	// Provider defines the interface of the election data shared by the committee of gravity chain and the native
+	// staking committee, such that the api could be served by either of them
+	Provider interface {
 		// Start starts the committee service
 		Start(context.Context) error
 		// Stop stops the committee service
 		Stop(context.Context) error
-		// ResultByHeight returns the result on a specific ethereum height
-		ResultByHeight(uint64) (*types.ElectionResult, error)
+		// LatestHeight returns the height with latest result
+		LatestHeight() uint64
 		//RawDataByHeight returns the bucket list and registration list and mintTime
 		RawDataByHeight(uint64) ([]*types.Bucket, []*types.Registration, time.Time, error)
+		// ResultByHeight returns the result on a specific height
+		ResultByHeight(uint64) (*types.ElectionResult, error)
 		// HeightByTime returns the nearest result before time
 		HeightByTime(time.Time) (uint64, error)
-		// LatestHeight returns the height with latest result
-		LatestHeight() uint64
 		// Status returns the committee status
 		Status() STATUS
+		// Health returns the detailed health of the committee
+		Health() *Health
+		// ScoreOverrides returns the score overrides applied on the results
+		ScoreOverrides() []ScoreOverride
+		// VoterHistory returns the votes of a voter at the heights in a given range
+		VoterHistory([]byte, uint64, uint64) ([]*VoterPoll, error)
+		// CandidateHistory returns the stats of a candidate at the heights in a given range with a given step
+		CandidateHistory([]byte, uint64, uint64, uint64) ([]*CandidatePoint, error)
+		// SubscribeHeights returns a channel of the lowest height changed, which is notified whenever heights are
+		// stored or rolled back, and a function to cancel the subscription
+		SubscribeHeights() (<-chan uint64, func())
+	}
+
+	// Committee defines an interface of an election committee
+	// It could be considered as a light state db of gravity chain, that
+	Committee interface {
+		Provider
 		// PutNativePollByEpoch puts one native poll record on IoTeX chain
 		PutNativePollByEpoch(uint64, time.Time, []*types.Bucket) error
 		// NativeBucketsByEpoch returns a list of Bucket of a given epoch number
 		NativeBucketsByEpoch(uint64) ([]*types.Bucket, error)
+		// ResultByEpoch returns the result of an IoTeX epoch, combining the buckets on gravity chain and the native
+		// buckets of the epoch
+		ResultByEpoch(uint64) (*types.ElectionResult, error)
+	}
+
+	// VoterPoll defines the votes of a voter at a height
+	VoterPoll struct {
+		Height   uint64
+		MintTime time.Time
+		Votes    []*types.Vote
 	}
 
 	committee struct {
@@ -109,7 +148,10 @@ type (
 		selfStakingThreshold  *big.Int
 		interval              uint64
 
-		cache *lru.Cache
+		cache        *lru.Cache
+		historyCache *lru.Cache
+		// rollbacks is the number of rollbacks purging the caches, guarded by mutex
+		rollbacks uint64
 
 		startHeight           uint64
 		currentHeight         uint64
@@ -120,10 +162,16 @@ type (
 		mutex                 sync.RWMutex
 		gravityChainBatchSize uint64
 		ceilingHeight         uint64
+		reorgCheckDepth       uint64
+		weightingSchedule     *types.WeightingSchedule
+		scoreOverrides        scoreOverrides
+		notifier              *heightNotifier
+		health                *healthTracker
 	}
 
 	rawData struct {
 		mintTime          time.Time
+		blockHash         hash.Hash256
 		noNewStakingEvent bool
 		migration         bool
 		buckets           []*types.Bucket
@@ -136,21 +184,68 @@ func NewCommittee(archive PollArchive, cfg Config) (Committee, error) {
 	if !common.IsHexAddress(cfg.StakingContractAddress) {
 		return nil, errors.New("Invalid staking contract address")
 	}
-	carrier, err := carrier.NewEthereumVoteCarrier(
-		12,
-		time.Minute,
-		cfg.GravityChainAPIs,
-		common.HexToAddress(cfg.RegisterContractAddress),
-		common.HexToAddress(cfg.StakingContractAddress),
-	)
+	voteCarrier, err := NewCarrier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewCommitteeWithCarrier(archive, voteCarrier, cfg)
+}
+
+// NewCarrier creates the carrier reading gravity chain data defined in cfg
+func NewCarrier(cfg Config) (carrier.Carrier, error) {
+	confirmHeight := uint64(12)
+	if cfg.ConfirmHeight > 0 {
+		confirmHeight = cfg.ConfirmHeight
+	}
+	quorum := carrier.QuorumConfig{
+		Size:      cfg.GravityChainQuorumSize,
+		Threshold: cfg.GravityChainQuorumThreshold,
+	}
+	var voteCarrier carrier.Carrier
+	var err error
+	if cfg.FetchBucketsFromEvents {
+		voteCarrier, err = carrier.NewEventVoteCarrier(
+			confirmHeight,
+			time.Minute,
+			cfg.GravityChainAPIs,
+			common.HexToAddress(cfg.RegisterContractAddress),
+			common.HexToAddress(cfg.StakingContractAddress),
+			quorum,
+			cfg.StakingContractStartHeight,
+			10000,
+		)
+	} else {
+		voteCarrier, err = carrier.NewEthereumVoteCarrier(
+			confirmHeight,
+			time.Minute,
+			cfg.GravityChainAPIs,
+			common.HexToAddress(cfg.RegisterContractAddress),
+			common.HexToAddress(cfg.StakingContractAddress),
+			quorum,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if cfg.RecordFixturePath != "" {
+		recordingCarrier, err := carrier.NewRecordingCarrier(voteCarrier, cfg.RecordFixturePath)
+		if err != nil {
+			voteCarrier.Close()
+			return nil, errors.Wrap(err, "failed to create fixture file")
+		}
+		voteCarrier = recordingCarrier
+		zap.L().Info("Recording carrier responses", zap.String("path", cfg.RecordFixturePath))
+	}
 	zap.L().Info(
 		"Carrier created",
 		zap.String("registerContractAddress", cfg.RegisterContractAddress),
 		zap.String("stakingContractAddress", cfg.StakingContractAddress),
 	)
+	return voteCarrier, nil
+}
+
+// NewCommitteeWithCarrier creates a committee reading gravity chain data via the given carrier
+func NewCommitteeWithCarrier(archive PollArchive, voteCarrier carrier.Carrier, cfg Config) (Committee, error) {
 	voteThreshold, ok := new(big.Int).SetString(cfg.VoteThreshold, 10)
 	if !ok {
 		return nil, errors.New("Invalid vote threshold")
@@ -171,14 +266,41 @@ func NewCommittee(archive PollArchive, cfg Config) (Committee, error) {
 	if cfg.GravityChainBatchSize > 0 {
 		gravityChainBatchSize = cfg.GravityChainBatchSize
 	}
+	reorgCheckDepth := uint64(10)
+	if cfg.ReorgCheckDepth > 0 {
+		reorgCheckDepth = cfg.ReorgCheckDepth
+	}
+	weightingSchedule, err := types.NewWeightingSchedule(types.GravityWeightingV1, cfg.WeightingPolicies)
+	if err != nil {
+		return nil, err
+	}
+	overrides := DefaultScoreOverrides()
+	if cfg.ScoreOverridesPath != "" {
+		if overrides, err = LoadScoreOverrides(cfg.ScoreOverridesPath); err != nil {
+			return nil, err
+		}
+	}
+	scoreOverrides, err := newScoreOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
 	cache, err := lru.New(int(cfg.CacheSize))
 	if err != nil {
 		return nil, err
 	}
+	historyCacheSize := 8192
+	if cfg.HistoryCacheSize > 0 {
+		historyCacheSize = int(cfg.HistoryCacheSize)
+	}
+	historyCache, err := lru.New(historyCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	return &committee{
 		archive:               archive,
 		cache:                 cache,
-		carrier:               carrier,
+		historyCache:          historyCache,
+		carrier:               voteCarrier,
 		retryLimit:            cfg.NumOfRetries,
 		paginationSize:        cfg.PaginationSize,
 		fetchInParallel:       fetchInParallel,
@@ -194,6 +316,11 @@ func NewCommittee(archive PollArchive, cfg Config) (Committee, error) {
 		interval:              cfg.GravityChainHeightInterval,
 		currentHeight:         0,
 		gravityChainBatchSize: gravityChainBatchSize,
+		reorgCheckDepth:       reorgCheckDepth,
+		weightingSchedule:     weightingSchedule,
+		scoreOverrides:        scoreOverrides,
+		notifier:              newHeightNotifier(),
+		health:                newHealthTracker(cfg.Health, 5*time.Minute, cfg.GravityChainHeightInterval),
 	}, nil
 }
 
@@ -216,6 +343,7 @@ func (ec *committee) Start(ctx context.Context) (err error) {
 	if err != nil {
 		return errors.Wrap(err, "failed to get tip height")
 	}
+	ec.health.observeTip(tip)
 	tipChan := make(chan uint64)
 	reportChan := make(chan error)
 	go func() {
@@ -225,17 +353,21 @@ func (ec *committee) Start(ctx context.Context) (err error) {
 			zap.L().Info("catching up to", zap.Uint64("height", h))
 			data, err := ec.fetchInBatch(h)
 			if err != nil {
+				ec.health.observeError(err)
 				zap.L().Error("failed to fetch data", zap.Error(err))
 			}
 			if err := ec.storeInBatch(data); err != nil {
+				ec.health.observeError(err)
 				zap.L().Error("failed to catch up via network", zap.Uint64("height", h), zap.Error(err))
 			}
 		}
 		data, err := ec.fetchInBatch(tip)
 		if err != nil {
+			ec.health.observeError(err)
 			zap.L().Error("failed to fetch data", zap.Error(err))
 		}
 		if err := ec.storeInBatch(data); err != nil {
+			ec.health.observeError(err)
 			zap.L().Error("failed to catch up via network", zap.Error(err))
 		}
 		zap.L().Info("subscribing to new block")
@@ -253,9 +385,11 @@ func (ec *committee) Start(ctx context.Context) (err error) {
 
 				zap.L().Info("new ethereum block", zap.Uint64("height", tip))
 				if err := ec.Sync(tip); err != nil {
+					ec.health.observeError(err)
 					zap.L().Error("failed to sync", zap.Error(err))
 				}
 			case err := <-reportChan:
+				ec.health.observeError(err)
 				zap.L().Error("something goes wrong", zap.Error(err))
 			}
 		}
@@ -289,23 +423,39 @@ func (ec *committee) Stop(ctx context.Context) error {
 }
 
 func (ec *committee) Status() STATUS {
-	lastUpdateTimestamp := atomic.LoadInt64(&ec.lastUpdateTimestamp)
-	switch {
-	case lastUpdateTimestamp == 0:
-		return STARTING
-	case lastUpdateTimestamp > time.Now().Add(-5*time.Minute).Unix():
-		return ACTIVE
-	default:
-		return INACTIVE
+	return ec.health.status(atomic.LoadInt64(&ec.lastUpdateTimestamp))
+}
+
+func (ec *committee) Health() *Health {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+	archivedHeight := ec.latestHeightInArchive()
+	var mintTime time.Time
+	if archivedHeight != 0 {
+		var err error
+		if mintTime, err = ec.archive.MintTime(archivedHeight); err != nil {
+			zap.L().Error("failed to get mint time", zap.Uint64("height", archivedHeight), zap.Error(err))
+		}
 	}
+	return ec.health.health(
+		atomic.LoadInt64(&ec.lastUpdateTimestamp),
+		archivedHeight,
+		mintTime,
+		ec.carrier.Endpoints(),
+	)
 }
 
 func (ec *committee) Sync(tipHeight uint64) error {
+	ec.health.observeTip(tipHeight)
+	if err := ec.checkReorg(); err != nil {
+		return errors.Wrap(err, "failed to check chain reorganization")
+	}
 	data, err := ec.fetchInBatch(tipHeight)
 	if err != nil {
 		return err
 	}
 	if len(data) == 0 {
+		reportSyncHeights(gravityCommitteeLabel, ec.currentHeight, ec.latestHeightInArchive())
 		return nil
 	}
 	ec.mutex.Lock()
@@ -314,6 +464,61 @@ func (ec *committee) Sync(tipHeight uint64) error {
 	return ec.storeInBatch(data)
 }
 
+// checkReorg compares the block hashes of the most recent polls in archive with the ones on gravity chain,
+// and rolls back the polls from the lowest mismatched height, such that they will be refetched
+func (ec *committee) checkReorg() error {
+	tipHeight := ec.latestHeightInArchive()
+	if tipHeight == 0 {
+		return nil
+	}
+	rollbackHeight := uint64(0)
+	height := tipHeight
+scan:
+	for i := uint64(0); i < ec.reorgCheckDepth && height >= ec.startHeight; i++ {
+		stored, err := ec.archive.BlockHash(height)
+		switch errors.Cause(err) {
+		case nil:
+			h, err := ec.carrier.BlockHash(height)
+			if err != nil {
+				return err
+			}
+			if hash.BytesToHash256(h.Bytes()) == stored {
+				break scan
+			}
+			rollbackHeight = height
+		case db.ErrNotExist:
+			// skip the polls archived without block hash
+		default:
+			return err
+		}
+		if height < ec.startHeight+ec.interval {
+			break
+		}
+		height -= ec.interval
+	}
+	if rollbackHeight == 0 {
+		return nil
+	}
+	zap.L().Warn("chain reorganization detected", zap.Uint64("height", rollbackHeight))
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+	if err := ec.archive.Rollback(rollbackHeight); err != nil {
+		return err
+	}
+	// the chain may be reorganized from any height after the last matched one
+	lastMatchedHeight := uint64(0)
+	if rollbackHeight >= ec.startHeight+ec.interval {
+		lastMatchedHeight = rollbackHeight - ec.interval
+	}
+	ec.carrier.Rollback(lastMatchedHeight)
+	ec.cache.Purge()
+	ec.historyCache.Purge()
+	ec.rollbacks++
+	ec.notifier.notify(rollbackHeight)
+
+	return nil
+}
+
 func (ec *committee) PutNativePollByEpoch(epochNum uint64, mintTime time.Time, buckets []*types.Bucket) error {
 	return ec.archive.PutNativePoll(epochNum, mintTime, buckets)
 }
@@ -322,6 +527,60 @@ func (ec *committee) NativeBucketsByEpoch(epochNum uint64) ([]*types.Bucket, err
 	return ec.archive.NativeBuckets(epochNum)
 }
 
+// epochKey is the key of the result of an epoch in cache, to tell it from the result of a height
+type epochKey uint64
+
+// ResultByEpoch returns the result of an IoTeX epoch. The registrations and buckets at the last gravity chain height
+// before the mint time of the epoch, and the native buckets of the epoch are weighted at the mint time of the epoch
+// together.
+func (ec *committee) ResultByEpoch(epochNum uint64) (*types.ElectionResult, error) {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+	value, ok := ec.cache.Get(epochKey(epochNum))
+	reportCacheLookup("epoch_result", ok)
+	if ok {
+		if result, as := value.(*types.ElectionResult); as {
+			return result, nil
+		}
+		return nil, errors.New("lru cache type assertion has error")
+	}
+	mintTime, err := ec.archive.NativeMintTime(epochNum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get native poll of epoch %d", epochNum)
+	}
+	height, err := ec.archive.HeightBefore(mintTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get gravity chain height before %s", mintTime)
+	}
+	nativeBuckets, err := ec.archive.NativeBuckets(epochNum)
+	if err != nil {
+		return nil, err
+	}
+	regs, err := ec.archive.Registrations(height)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := ec.archive.Buckets(height)
+	if err != nil {
+		return nil, err
+	}
+	calculator := ec.calculatorAt(height, mintTime)
+	if err := calculator.AddRegistrations(regs); err != nil {
+		return nil, err
+	}
+	if err := calculator.AddBuckets(append(append([]*types.Bucket{}, buckets...), nativeBuckets...)); err != nil {
+		return nil, err
+	}
+	result, err := calculator.Calculate()
+	if err != nil {
+		return nil, err
+	}
+	ec.scoreOverrides.apply(height, result)
+	ec.cache.Add(epochKey(epochNum), result)
+
+	return result, nil
+}
+
 func (ec *committee) nextHeight() uint64 {
 	height := ec.latestHeightInArchive()
 	if height == 0 {
@@ -331,6 +590,7 @@ func (ec *committee) nextHeight() uint64 {
 }
 
 func (ec *committee) fetchInBatch(tipHeight uint64) (retval map[uint64]*rawData, err error) {
+	defer reportFetchInBatch(gravityCommitteeLabel, time.Now())
 	if ec.currentHeight < tipHeight {
 		ec.currentHeight = tipHeight
 	}
@@ -363,11 +623,13 @@ func (ec *committee) fetchInBatch(tipHeight uint64) (retval map[uint64]*rawData,
 func (ec *committee) storeInBatch(data map[uint64]*rawData) error {
 	heights := make([]uint64, 0, len(data))
 	mintTimes := make([]time.Time, 0, len(data))
+	blockHashes := make([]hash.Hash256, 0, len(data))
 	arrOfRegs := make([][]*types.Registration, 0, len(data))
 	arrOfBuckets := make([][]*types.Bucket, 0, len(data))
 	for height := range data {
 		heights = append(heights, height)
 		mintTimes = append(mintTimes, data[height].mintTime)
+		blockHashes = append(blockHashes, data[height].blockHash)
 		arrOfRegs = append(arrOfRegs, data[height].registrations)
 		if data[height].noNewStakingEvent {
 			arrOfBuckets = append(arrOfBuckets, nil)
@@ -387,11 +649,15 @@ func (ec *committee) storeInBatch(data map[uint64]*rawData) error {
 	})
 	for _, height := range heights {
 		index := indice[height]
-		if err := ec.archive.PutPoll(height, mintTimes[index], arrOfRegs[index], arrOfBuckets[index]); err != nil {
+		if err := ec.archive.PutPoll(height, mintTimes[index], blockHashes[index], arrOfRegs[index], arrOfBuckets[index]); err != nil {
 			return err
 		}
 	}
 	atomic.StoreInt64(&ec.lastUpdateTimestamp, time.Now().Unix())
+	reportSyncHeights(gravityCommitteeLabel, ec.currentHeight, ec.latestHeightInArchive())
+	if len(heights) != 0 {
+		ec.notifier.notify(heights[0])
+	}
 	return nil
 }
 
@@ -461,7 +727,9 @@ func (ec *committee) resultByHeight(height uint64) (*types.ElectionResult, error
 		)
 	}
 
-	if cacheResult, ok := ec.cache.Get(height); ok {
+	cacheResult, ok := ec.cache.Get(height)
+	reportCacheLookup("result", ok)
+	if ok {
 		if result, as := cacheResult.(*types.ElectionResult); as {
 			return result, nil
 		}
@@ -469,65 +737,65 @@ func (ec *committee) resultByHeight(height uint64) (*types.ElectionResult, error
 			"lru cache type assertion has error",
 		)
 	}
-
-	//calculate the result from DB
-	calculator, err := ec.calculator(height, true)
-	if err != nil {
-		return nil, err
-	}
-	regs, err := ec.archive.Registrations(height)
+	result, err := ec.calculateResult(height)
 	if err != nil {
 		return nil, err
 	}
-	if err := calculator.AddRegistrations(regs); err != nil {
-		return nil, err
-	}
-	buckets, err := ec.archive.Buckets(height)
-	if err != nil {
-		return nil, err
-	}
-	if err := calculator.AddBuckets(buckets); err != nil {
-		return nil, err
-	}
-	result, err := calculator.Calculate()
-	if err != nil {
-		return nil, err
-	}
-	if err := ec.handleEthereumHardFork(height, result); err != nil {
-		return nil, err
-	}
 	ec.cache.Add(height, result)
 
 	return result, nil
 }
 
-func (ec *committee) calcWeightedVotes(v *types.Bucket, now time.Time) *big.Int {
-	if now.Before(v.StartTime()) {
-		return big.NewInt(0)
+// calculateResult calculates the result of height from DB
+func (ec *committee) calculateResult(height uint64) (*types.ElectionResult, error) {
+	calculate, err := ec.loadResult(height)
+	if err != nil {
+		return nil, err
 	}
-	remainingTime := v.RemainingTime(now).Seconds()
-	weight := float64(1)
-	if remainingTime > 0 {
-		weight += math.Log(math.Ceil(remainingTime/86400)) / math.Log(1.2) / 100
+	return calculate()
+}
+
+// loadResult reads the data of height from DB, and returns the calculation of the result from it, which is free of DB
+// access and could be run without holding the mutex
+func (ec *committee) loadResult(height uint64) (func() (*types.ElectionResult, error), error) {
+	buckets, regs, timestamp, err := ec.rawDataByHeight(height)
+	if err != nil {
+		return nil, err
 	}
-	amount := new(big.Float).SetInt(v.Amount())
-	weightedAmount, _ := amount.Mul(amount, big.NewFloat(weight)).Int(nil)
+	return func() (*types.ElectionResult, error) {
+		calculator := ec.calculatorAt(height, timestamp)
+		if err := calculator.AddRegistrations(regs); err != nil {
+			return nil, err
+		}
+		if err := calculator.AddBuckets(buckets); err != nil {
+			return nil, err
+		}
+		result, err := calculator.Calculate()
+		if err != nil {
+			return nil, err
+		}
+		ec.scoreOverrides.apply(height, result)
 
-	return weightedAmount
+		return result, nil
+	}, nil
 }
 
-func (ec *committee) fetchBucketsByHeight(height uint64, force bool) (bool, []*types.Bucket, error) {
+func (ec *committee) fetchBucketsByHeight(
+	height uint64,
+	blockHash common.Hash,
+	force bool,
+) (bool, []*types.Bucket, error) {
 	if height > ec.interval && height != ec.startHeight && !force {
 		if !ec.carrier.HasStakingEvents(new(big.Int).SetUint64(height-ec.interval+1), new(big.Int).SetUint64(height)) {
 			return true, nil, nil
 		}
 	}
-	buckets, err := ec.fetchBucketsFromEthereum(height)
+	buckets, err := ec.fetchBucketsFromEthereum(height, blockHash)
 
 	return false, buckets, err
 }
 
-func (ec *committee) fetchBucketsFromEthereum(height uint64) ([]*types.Bucket, error) {
+func (ec *committee) fetchBucketsFromEthereum(height uint64, blockHash common.Hash) ([]*types.Bucket, error) {
 	var allBuckets []*types.Bucket
 	previousIndex := big.NewInt(0)
 	for {
@@ -535,6 +803,7 @@ func (ec *committee) fetchBucketsFromEthereum(height uint64) ([]*types.Bucket, e
 		var err error
 		if previousIndex, buckets, err = ec.carrier.Buckets(
 			height,
+			blockHash,
 			previousIndex,
 			ec.paginationSize,
 		); err != nil {
@@ -583,16 +852,25 @@ func (ec *committee) calculator(height uint64, dbflag bool) (*types.ResultCalcul
 		return nil, err
 	}
 
+	return ec.calculatorAt(height, timestamp), nil
+}
+
+// calculatorAt returns a calculator weighting the votes at timestamp with the policy of height
+func (ec *committee) calculatorAt(height uint64, timestamp time.Time) *types.ResultCalculator {
+	policy := ec.weightingSchedule.PolicyAt(height)
+
 	return types.NewResultCalculator(
 		timestamp,
 		ec.skipManifiedCandidate,
 		ec.bucketFilter,
-		ec.calcWeightedVotes,
+		func(v *types.Bucket, now time.Time) *big.Int {
+			return types.WeightedVotes(policy, v, now)
+		},
 		ec.candidateFilter,
-	), nil
+	)
 }
 
-func (ec *committee) fetchRegistrationsByHeight(height uint64) ([]*types.Registration, error) {
+func (ec *committee) fetchRegistrationsByHeight(height uint64, blockHash common.Hash) ([]*types.Registration, error) {
 	var allCandidates []*types.Registration
 	previousIndex := big.NewInt(1)
 	for {
@@ -600,6 +878,7 @@ func (ec *committee) fetchRegistrationsByHeight(height uint64) ([]*types.Registr
 		var err error
 		if previousIndex, candidates, err = ec.carrier.Registrations(
 			height,
+			blockHash,
 			previousIndex,
 			ec.paginationSize,
 		); err != nil {
@@ -631,14 +910,14 @@ func (ec *committee) fetchResultByHeight(height uint64) (*types.ElectionResult,
 	if err != nil {
 		return nil, err
 	}
-	regs, err := ec.fetchRegistrationsByHeight(height)
+	regs, err := ec.fetchRegistrationsByHeight(height, common.Hash{})
 	if err != nil {
 		return nil, err
 	}
 	if err := calculator.AddRegistrations(regs); err != nil {
 		return nil, err
 	}
-	_, buckets, err := ec.fetchBucketsByHeight(height, true)
+	_, buckets, err := ec.fetchBucketsByHeight(height, common.Hash{}, true)
 	if err != nil {
 		return nil, err
 	}
@@ -649,19 +928,23 @@ func (ec *committee) fetchResultByHeight(height uint64) (*types.ElectionResult,
 	if err != nil {
 		return nil, err
 	}
-	if err := ec.handleEthereumHardFork(height, res); err != nil {
-		return nil, err
-	}
+	ec.scoreOverrides.apply(height, res)
 	return res, nil
 }
 
-func (ec *committee) fetchDataByHeight(height uint64) (*rawData, error) {
+// fetchDataByHeight fetches the data of height pinned to the block hash read first, such that the registrations and
+// buckets are read at the same block, and fails if the block of height is reorganized during the fetch
+func (ec *committee) fetchDataByHeight(height uint64, force bool) (*rawData, error) {
 	zap.L().Info("fetch from ethereum", zap.Uint64("height", height))
-	regs, err := ec.fetchRegistrationsByHeight(height)
+	blockHash, err := ec.carrier.BlockHash(height)
 	if err != nil {
 		return nil, err
 	}
-	noChange, buckets, err := ec.fetchBucketsByHeight(height, false)
+	regs, err := ec.fetchRegistrationsByHeight(height, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	noChange, buckets, err := ec.fetchBucketsByHeight(height, blockHash, force)
 	if err != nil {
 		return nil, err
 	}
@@ -669,15 +952,59 @@ func (ec *committee) fetchDataByHeight(height uint64) (*rawData, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the staking event check and the block timestamp are read by number, which are verified against the hash
+	h, err := ec.carrier.BlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	if h != blockHash {
+		return nil, errors.Errorf("block %d is reorganized from %s to %s during fetch", height, blockHash.Hex(), h.Hex())
+	}
 
 	return &rawData{
 		mintTime:          mintTime,
+		blockHash:         hash.BytesToHash256(blockHash.Bytes()),
 		noNewStakingEvent: noChange,
 		registrations:     regs,
 		buckets:           buckets,
 	}, nil
 }
 
+// RepairArchive refetches the polls of the inconsistent heights from gravity chain to repair the archive in place, and
+// rolls back from the first height failed to refetch, which is returned, or 0 if nothing is rolled back
+func RepairArchive(archive PollArchive, cfg Config, inconsistencies []*Inconsistency) (uint64, error) {
+	voteCarrier, err := NewCarrier(cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer voteCarrier.Close()
+	c, err := NewCommitteeWithCarrier(archive, voteCarrier, cfg)
+	if err != nil {
+		return 0, err
+	}
+	return c.(*committee).repair(inconsistencies)
+}
+
+func (ec *committee) repair(inconsistencies []*Inconsistency) (uint64, error) {
+	polls := []*RepairedPoll{}
+	for _, inconsistency := range inconsistencies {
+		// the buckets are always fetched, as the ones of the previous height may be inconsistent as well
+		data, err := ec.fetchDataByHeight(inconsistency.Height, true)
+		if err != nil {
+			zap.L().Error("failed to refetch poll", zap.Uint64("height", inconsistency.Height), zap.Error(err))
+			break
+		}
+		polls = append(polls, &RepairedPoll{
+			Height:        inconsistency.Height,
+			MintTime:      data.mintTime,
+			BlockHash:     data.blockHash,
+			Registrations: data.registrations,
+			Buckets:       data.buckets,
+		})
+	}
+	return ec.archive.Repair(inconsistencies, polls)
+}
+
 func atos(a []int64) string {
 	if len(a) == 0 {
 		return ""
@@ -690,80 +1017,22 @@ func atos(a []int64) string {
 	return strings.Join(b, ",")
 }
 
-func (ec *committee) handleEthereumHardFork(height uint64, result *types.ElectionResult) error {
-	if height != EthHardForkHeight {
-		return nil
-	}
-	for _, delegate := range result.Delegates() {
-		name := hex.EncodeToString(delegate.Name())
-		switch name {
-		case "000000696f746578636f7265":
-			score, ok := new(big.Int).SetString("85373235544231218078559584", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "00000000006d6574616e7978":
-			score, ok := new(big.Int).SetString("59632560935643656968902530", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "67616d6566616e7461737900":
-			score, ok := new(big.Int).SetString("53200765151851838442704552", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "00000000707265616e67656c":
-			score, ok := new(big.Int).SetString("50419789330925706718338211", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "00007976616c696461746f72":
-			score, ok := new(big.Int).SetString("49956076291800440218188229", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "000000636f696e6765636b6f":
-			score, ok := new(big.Int).SetString("45541967921325925112783154", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "000000696f7465787465616d":
-			score, ok := new(big.Int).SetString("42048368188254741149181523", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "0000626c6f636b666f6c696f":
-			score, ok := new(big.Int).SetString("40118847473353343676639849", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "696f7478706c6f726572696f":
-			score, ok := new(big.Int).SetString("38637407472542613934244717", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "0068756f626977616c6c6574":
-			score, ok := new(big.Int).SetString("19826333897499304850764901", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		case "636f6e73656e7375736e6574":
-			score, ok := new(big.Int).SetString("4562820963931216603918007", 10)
-			if ok {
-				delegate.SetScore(score)
-			}
-		default:
-			continue
-		}
-	}
-	return nil
+// SubscribeHeights returns a channel of the lowest height changed and a function to cancel the subscription
+func (ec *committee) SubscribeHeights() (<-chan uint64, func()) {
+	return ec.notifier.subscribe()
+}
+
+// ScoreOverrides returns the score overrides applied on the results
+func (ec *committee) ScoreOverrides() []ScoreOverride {
+	return ec.scoreOverrides.list()
 }
 
 func (ec *committee) retryFetchDataByHeight(height uint64) (data *rawData, err error) {
 	for i := uint8(0); i < ec.retryLimit; i++ {
-		if data, err = ec.fetchDataByHeight(height); err == nil {
+		if data, err = ec.fetchDataByHeight(height, false); err == nil {
 			break
 		}
+		fetchRetryMtc.WithLabelValues(gravityCommitteeLabel).Inc()
 		zap.L().Error(
 			"failed to fetch result by height",
 			zap.Error(err),
@@ -773,3 +1042,48 @@ func (ec *committee) retryFetchDataByHeight(height uint64) (data *rawData, err e
 	}
 	return
 }
+
+// VoterHistory returns the votes of a voter at the heights in [fromHeight, toHeight] sorted by height, skipping the
+// heights without vote. The votes are weighted with the policy in effect at each height. The range could cover no more
+// than MaxCandidateHistoryPoints heights.
+func (ec *committee) VoterHistory(voter []byte, fromHeight uint64, toHeight uint64) ([]*VoterPoll, error) {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+	heights, err := historyHeights(ec.startHeight, ec.interval, ec.latestHeightInArchive(), fromHeight, toHeight, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(heights) == 0 {
+		return []*VoterPoll{}, nil
+	}
+	heightToBuckets, err := ec.archive.VoterBuckets(voter, heights[0], heights[len(heights)-1])
+	if err != nil {
+		return nil, err
+	}
+	polls := make([]*VoterPoll, 0, len(heightToBuckets))
+	for _, height := range heights {
+		if _, ok := heightToBuckets[height]; !ok {
+			continue
+		}
+		mintTime, err := ec.archive.MintTime(height)
+		if err != nil {
+			return nil, err
+		}
+		policy := ec.weightingSchedule.PolicyAt(height)
+		poll := &VoterPoll{Height: height, MintTime: mintTime}
+		for _, bucket := range heightToBuckets[height] {
+			if ec.bucketFilter(bucket) {
+				continue
+			}
+			vote, err := types.NewVote(bucket, types.WeightedVotes(policy, bucket, mintTime))
+			if err != nil {
+				return nil, err
+			}
+			poll.Votes = append(poll.Votes, vote)
+		}
+		if len(poll.Votes) != 0 {
+			polls = append(polls, poll)
+		}
+	}
+	return polls, nil
+}
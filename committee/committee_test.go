@@ -12,6 +12,8 @@ package committee
 
 import (
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -22,7 +24,8 @@ import (
 
 func TestCalcWeightedVotes(t *testing.T) {
 	require := require.New(t)
-	committee := &committee{}
+	policy, err := types.WeightingPolicyByName(types.GravityWeightingV1)
+	require.NoError(err)
 	startTime := time.Now()
 	duration := time.Hour * 24 * 14
 	bucket1, err := types.NewBucket(
@@ -35,13 +38,13 @@ func TestCalcWeightedVotes(t *testing.T) {
 	)
 	require.NoError(err)
 	// now.Before(v.StartTime()),return 0
-	require.Equal(0, committee.calcWeightedVotes(bucket1, startTime.Add(-1*time.Hour)).Cmp(big.NewInt(0)))
+	require.Equal(0, types.WeightedVotes(policy, bucket1, startTime.Add(-1*time.Hour)).Cmp(big.NewInt(0)))
 
 	// decay is true,startTime+duration is after now,remainingTime is 24*14-24=13*24 hours,weight is ~1.140,ret is 3422048
-	require.Equal(0, committee.calcWeightedVotes(bucket1, startTime.Add(time.Hour*24)).Cmp(big.NewInt(3422048)))
+	require.Equal(0, types.WeightedVotes(policy, bucket1, startTime.Add(time.Hour*24)).Cmp(big.NewInt(3422048)))
 
 	// decay is true,startTime+duration is before now,remainingTime is 0 hours,weight is 1,ret is 3000000
-	require.Equal(0, committee.calcWeightedVotes(bucket1, time.Now().Add(24*15*time.Hour)).Cmp(big.NewInt(3000000)))
+	require.Equal(0, types.WeightedVotes(policy, bucket1, time.Now().Add(24*15*time.Hour)).Cmp(big.NewInt(3000000)))
 
 	bucket2, err := types.NewBucket(
 		startTime,
@@ -53,8 +56,8 @@ func TestCalcWeightedVotes(t *testing.T) {
 	)
 	require.NoError(err)
 	// decay is false,remainingTime is duration,weight ~1.144，ret is 3434242,whatever now is
-	require.Equal(0, committee.calcWeightedVotes(bucket2, startTime.Add(time.Hour*24)).Cmp(big.NewInt(3434242)))
-	require.Equal(0, committee.calcWeightedVotes(bucket2, startTime.Add(24*15*time.Hour)).Cmp(big.NewInt(3434242)))
+	require.Equal(0, types.WeightedVotes(policy, bucket2, startTime.Add(time.Hour*24)).Cmp(big.NewInt(3434242)))
+	require.Equal(0, types.WeightedVotes(policy, bucket2, startTime.Add(24*15*time.Hour)).Cmp(big.NewInt(3434242)))
 }
 
 func TestVoteFilter(t *testing.T) {
@@ -142,3 +145,28 @@ func TestCandidateFilter(t *testing.T) {
 	)
 	require.False(committee.candidateFilter(candidate4))
 }
+
+func TestNewCarrierRecording(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "fixture.jsonl")
+	c, err := NewCarrier(Config{
+		GravityChainAPIs:        []string{"http://127.0.0.1:1"},
+		RegisterContractAddress: "0x95724986563028deb58f15c5fac19fa09304f32d",
+		StakingContractAddress:  "0x87c9dbff0016af23f5b1ab9b8e072124ab729193",
+		RecordFixturePath:       path,
+	})
+	require.NoError(err)
+	// the failed request is recorded as well
+	_, err = c.BlockTimestamp(100)
+	require.Error(err)
+	c.Close()
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	require.Contains(string(data), `"method":"BlockTimestamp"`)
+
+	_, err = NewCarrier(Config{
+		GravityChainAPIs:  []string{"http://127.0.0.1:1"},
+		RecordFixturePath: filepath.Join(t.TempDir(), "missing", "fixture.jsonl"),
+	})
+	require.Error(err)
+}
@@ -48,14 +48,23 @@ func NewDeltaRecordTableOperator(
 	queryRecordsFunc QueryRecordsFunc,
 	recordTableCreation string,
 ) (Operator, error) {
-	var insertHeightToRecordsQuery, insertIdenticalQuery string
+	var insertHeightToRecordsQuery, insertIdenticalQuery, heightToRecordsCreation, identicalCreation string
 	switch driverName {
 	case SQLITE:
 		insertHeightToRecordsQuery = fmt.Sprintf("INSERT OR REPLACE INTO height_to_%s (height, ids, frequencies, indexes) VALUES (?, ?, ?, ?)", tableName)
 		insertIdenticalQuery = fmt.Sprintf("INSERT OR IGNORE INTO identical_%s (height, identical_to) VALUES (?, ?)", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height INTEGER PRIMARY KEY, ids BLOB, frequencies BLOB, indexes BLOB)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height INTEGER PRIMARY KEY, identical_to INTEGER)"
 	case MYSQL:
 		insertHeightToRecordsQuery = fmt.Sprintf("REPLACE INTO height_to_%s (height, ids, frequencies, indexes) VALUES (?, ?, ?, ?)", tableName)
 		insertIdenticalQuery = fmt.Sprintf("INSERT IGNORE INTO identical_%s (height, identical_to) VALUES (?, ?)", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height BIGINT PRIMARY KEY, ids LONGBLOB, frequencies LONGBLOB, indexes LONGBLOB)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height BIGINT PRIMARY KEY, identical_to BIGINT)"
+	case POSTGRES:
+		insertHeightToRecordsQuery = fmt.Sprintf("INSERT INTO height_to_%s (height, ids, frequencies, indexes) VALUES (?, ?, ?, ?) ON CONFLICT (height) DO UPDATE SET ids = EXCLUDED.ids, frequencies = EXCLUDED.frequencies, indexes = EXCLUDED.indexes", tableName)
+		insertIdenticalQuery = fmt.Sprintf("INSERT INTO identical_%s (height, identical_to) VALUES (?, ?) ON CONFLICT DO NOTHING", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height BIGINT PRIMARY KEY, ids BYTEA, frequencies BYTEA, indexes BYTEA)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height BIGINT PRIMARY KEY, identical_to BIGINT)"
 	default:
 		return nil, errors.New("Wrong driver type")
 	}
@@ -63,22 +72,27 @@ func NewDeltaRecordTableOperator(
 		recordTableOperator{
 			tableName:                  tableName,
 			driverName:                 driverName,
-			frequencyQuery:             fmt.Sprintf("SELECT ids, frequencies FROM height_to_%s WHERE height = ?", tableName),
+			frequencyQuery:             rebind(driverName, fmt.Sprintf("SELECT ids, frequencies FROM height_to_%s WHERE height = ?", tableName)),
 			hashQuery:                  fmt.Sprintf("SELECT id, hash FROM %s WHERE id IN (%s)", tableName, "%s"),
 			idQuery:                    fmt.Sprintf("SELECT id, hash FROM %s WHERE hash IN ('%s')", tableName, "%s"),
-			identicalQuery:             fmt.Sprintf("SELECT identical_to FROM identical_%s WHERE height = ?", tableName),
-			lastHeightQuery:            fmt.Sprintf("SELECT MAX(max_height) FROM (SELECT MAX(height) AS max_height FROM identical_%s WHERE height < ? UNION SELECT MAX(height) AS max_height FROM height_to_%s WHERE height < ?) AS height", tableName, tableName),
-			insertHeightToRecordsQuery: insertHeightToRecordsQuery,
-			insertIdenticalQuery:       insertIdenticalQuery,
+			identicalQuery:             rebind(driverName, fmt.Sprintf("SELECT identical_to FROM identical_%s WHERE height = ?", tableName)),
+			lastHeightQuery:            rebind(driverName, fmt.Sprintf("SELECT MAX(max_height) FROM (SELECT MAX(height) AS max_height FROM identical_%s WHERE height < ? UNION SELECT MAX(height) AS max_height FROM height_to_%s WHERE height < ?) AS height", tableName, tableName)),
+			insertHeightToRecordsQuery: rebind(driverName, insertHeightToRecordsQuery),
+			insertIdenticalQuery:       rebind(driverName, insertIdenticalQuery),
 			queryRecordsFunc:           queryRecordsFunc,
+			deleteRecordsQuery:         fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", tableName, "%s"),
+			rollbackQueries: []string{
+				rebind(driverName, fmt.Sprintf("DELETE FROM height_to_%s WHERE height >= ?", tableName)),
+				rebind(driverName, fmt.Sprintf("DELETE FROM identical_%s WHERE height >= ?", tableName)),
+			},
 			tableCreations: []string{
 				fmt.Sprintf(recordTableCreation, tableName),
-				fmt.Sprintf("CREATE TABLE IF NOT EXISTS height_to_%s (height INTEGER PRIMARY KEY, ids BLOB, frequencies BLOB, indexes BLOB)", tableName),
-				fmt.Sprintf("CREATE TABLE IF NOT EXISTS identical_%s (height INTEGER PRIMARY KEY, identical_to INTEGER)", tableName),
+				fmt.Sprintf(heightToRecordsCreation, tableName),
+				fmt.Sprintf(identicalCreation, tableName),
 			},
 		},
 		insertDeltaRecordsFunc,
-		fmt.Sprintf("SELECT ids, indexes FROM height_to_%s WHERE height = ?", tableName),
+		rebind(driverName, fmt.Sprintf("SELECT ids, indexes FROM height_to_%s WHERE height = ?", tableName)),
 	}, nil
 }
 
@@ -90,6 +104,17 @@ func (op *deltaRecordTableOperator) Get(height uint64, db *sql.DB, tx *sql.Tx) (
 	return op.recordTableOperator.Get(height, db, tx)
 }
 
+// Verify checks the records of height as a record table, and the indexes of the records
+func (op *deltaRecordTableOperator) Verify(height uint64, sdb *sql.DB) error {
+	if err := op.recordTableOperator.Verify(height, sdb); err != nil {
+		return err
+	}
+	if _, _, err := op.indexes(height, sdb, nil); err != nil {
+		return errors.Wrapf(ErrInconsistent, "invalid indexes of %s at height %d: %v", op.tableName, height, err)
+	}
+	return nil
+}
+
 func (op *deltaRecordTableOperator) indexes(height uint64, sdb *sql.DB, tx *sql.Tx) (
 	uint64,
 	map[int64]hash.Hash256,
@@ -173,7 +198,8 @@ func (op *deltaRecordTableOperator) Put(height uint64, records interface{}, tx *
 	if err != nil {
 		return errors.Wrap(err, "failed to get record hashes")
 	}
-	if len(hash2Frequencies) == 0 {
+	// a delta removing records only is not identical to the last height
+	if len(index2Hash) == 0 {
 		if _, err := tx.Exec(op.insertIdenticalQuery, height, lastIdenticalHeight); err != nil {
 			return err
 		}
@@ -278,6 +304,8 @@ func InsertDeltaBuckets(tableName string, driverName DRIVERTYPE, records interfa
 		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQuerySQLITE, tableName))
 	case MYSQL:
 		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQueryMySQL, tableName))
+	case POSTGRES:
+		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQueryPostgres, tableName))
 	default:
 		return nil, nil, errors.New("wrong driver type")
 	}
@@ -311,7 +339,7 @@ func InsertDeltaBuckets(tableName string, driverName DRIVERTYPE, records interfa
 			bucket.StartTime,
 			bucket.Duration.String(),
 			bucket.Amount.Bytes(),
-			bucket.Decay,
+			boolToInt64(bucket.Decay),
 			bucket.Owner.Bytes(),
 			bucket.CanName[:],
 		); err != nil {
@@ -330,6 +358,8 @@ func NewDeltaBucketTableOperator(tableName string, driverName DRIVERTYPE) (Opera
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTOINCREMENT, hash TEXT UNIQUE, start_time TIMESTAMP, duration TEXT, amount BLOB, decay INTEGER, voter BLOB, candidate BLOB)"
 	case MYSQL:
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTO_INCREMENT, hash VARCHAR(64) UNIQUE, start_time TIMESTAMP, duration TEXT, amount BLOB, decay INTEGER, voter BLOB, candidate BLOB)"
+	case POSTGRES:
+		creation = "CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, hash VARCHAR(64) UNIQUE, start_time TIMESTAMPTZ, duration TEXT, amount BYTEA, decay INTEGER, voter BYTEA, candidate BYTEA)"
 	default:
 		return nil, errors.New("Wrong driver type")
 	}
@@ -0,0 +1,66 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+func TestDeltaRecordTableOperatorRemoval(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	arch, err := NewBucketArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "native.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	defer func() {
+		require.NoError(arch.Stop(ctx))
+	}()
+	owner, err := address.FromBytes(make([]byte, 20))
+	require.NoError(err)
+	bucket := func(index uint64) *pyggBucket {
+		return &pyggBucket{Index: index, CanName: [12]byte{11: 'a'}, Amount: big.NewInt(int64(index)), StartTime: time.Unix(0, 0), Owner: owner}
+	}
+	deltas := [][]*pyggBucket{
+		{bucket(1), bucket(2)},
+		// a delta removing buckets only
+		{{Index: 1}},
+		// no delta
+		nil,
+		{{Index: 2}},
+	}
+	for i, delta := range deltas {
+		height := 100 + uint64(i)*10
+		require.NoError(arch.PutDelta(height, time.Unix(int64(1600000000+height*5), 0), hash.Hash256b([]byte{byte(height)}), delta))
+	}
+	for height, amounts := range map[uint64][]int64{
+		100: {1, 2},
+		110: {2},
+		120: {2},
+		130: {},
+	} {
+		buckets, err := arch.Buckets(height)
+		require.NoError(err)
+		require.Equal(len(amounts), len(buckets), "height %d", height)
+		for i, amount := range amounts {
+			require.Equal(0, buckets[i].Amount().Cmp(big.NewInt(amount)))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	// require postgres driver
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	// require sqlite3 driver
+	_ "modernc.org/sqlite"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+// ParseDriverType returns the driver type of a driver name, with sqlite as default
+func ParseDriverType(name string) (DRIVERTYPE, error) {
+	switch strings.ToLower(name) {
+	case "", "sqlite", "sqlite3":
+		return SQLITE, nil
+	case "mysql":
+		return MYSQL, nil
+	case "postgres", "postgresql":
+		return POSTGRES, nil
+	default:
+		return 0, errors.Errorf("unsupported sql driver %s", name)
+	}
+}
+
+// openDB opens the sql db defined in cfg. A sqlite db is stored in cfg.DBPath, while an external db is connected
+// via cfg.DSN.
+func openDB(driverName DRIVERTYPE, cfg db.Config) (sqlDB *sql.DB, err error) {
+	switch driverName {
+	case SQLITE:
+		sqlDB, err = sql.Open("sqlite", cfg.DBPath)
+	case MYSQL:
+		var mysqlCfg *mysql.Config
+		if mysqlCfg, err = mysql.ParseDSN(cfg.DSN); err != nil {
+			return nil, errors.Wrap(err, "invalid mysql dsn")
+		}
+		// timestamps are scanned into time.Time in UTC
+		mysqlCfg.ParseTime = true
+		mysqlCfg.Loc = time.UTC
+		sqlDB, err = sql.Open("mysql", mysqlCfg.FormatDSN())
+	case POSTGRES:
+		if cfg.DSN == "" {
+			return nil, errors.New("empty postgres dsn")
+		}
+		sqlDB, err = sql.Open("postgres", cfg.DSN)
+	default:
+		err = errors.New("wrong driver type")
+	}
+	return sqlDB, err
+}
+
+// rebind converts the ? placeholders in query into the placeholders of driver
+func rebind(driverName DRIVERTYPE, query string) string {
+	if driverName != POSTGRES {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			sb.WriteRune(c)
+			continue
+		}
+		n++
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(n))
+	}
+	return sb.String()
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+func TestDriver(t *testing.T) {
+	require := require.New(t)
+	for name, expected := range map[string]DRIVERTYPE{
+		"":         SQLITE,
+		"sqlite":   SQLITE,
+		"MySQL":    MYSQL,
+		"postgres": POSTGRES,
+	} {
+		driverName, err := ParseDriverType(name)
+		require.NoError(err)
+		require.Equal(expected, driverName)
+	}
+	_, err := ParseDriverType("oracle")
+	require.Error(err)
+
+	query := "SELECT MAX(height) FROM t WHERE ? >= time AND EXISTS (SELECT * FROM t WHERE ? <= time)"
+	require.Equal(query, rebind(SQLITE, query))
+	require.Equal(query, rebind(MYSQL, query))
+	require.Equal(
+		"SELECT MAX(height) FROM t WHERE $1 >= time AND EXISTS (SELECT * FROM t WHERE $2 <= time)",
+		rebind(POSTGRES, query),
+	)
+
+	_, err = openDB(MYSQL, db.Config{DSN: "invalid"})
+	require.Error(err)
+	_, err = openDB(POSTGRES, db.Config{})
+	require.Error(err)
+	sqlDB, err := openDB(MYSQL, db.Config{DSN: "user:password@tcp(127.0.0.1:3306)/election"})
+	require.NoError(err)
+	require.NoError(sqlDB.Close())
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	// require sqlite3 driver
+	"github.com/pkg/errors"
+	_ "modernc.org/sqlite"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/util"
+)
+
+// HashTableOperator defines an operator on a table of block hashes
+type HashTableOperator struct {
+	createTableQuery string
+	insertHashQuery  string
+	hashQuery        string
+	tipHeightQuery   string
+	rollbackQuery    string
+}
+
+// NewHashTableOperator returns an operator to hash table
+func NewHashTableOperator(tableName string, driverName DRIVERTYPE) *HashTableOperator {
+	var insertHashQuery, createTableQuery string
+	switch driverName {
+	case SQLITE:
+		insertHashQuery = fmt.Sprintf("INSERT OR REPLACE INTO %s (height, hash) VALUES (?, ?)", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height INTEGER PRIMARY KEY, hash TEXT)", tableName)
+	case MYSQL:
+		insertHashQuery = fmt.Sprintf("REPLACE INTO %s (height, hash) VALUES (?, ?)", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY, hash VARCHAR(64))", tableName)
+	case POSTGRES:
+		insertHashQuery = fmt.Sprintf("INSERT INTO %s (height, hash) VALUES (?, ?) ON CONFLICT (height) DO UPDATE SET hash = EXCLUDED.hash", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY, hash VARCHAR(64))", tableName)
+	default:
+		return nil
+	}
+	return &HashTableOperator{
+		insertHashQuery:  rebind(driverName, insertHashQuery),
+		createTableQuery: createTableQuery,
+		hashQuery:        rebind(driverName, fmt.Sprintf("SELECT hash FROM %s WHERE height = ?", tableName)),
+		tipHeightQuery:   fmt.Sprintf("SELECT MAX(height) FROM %s", tableName),
+		rollbackQuery:    rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height >= ?", tableName)),
+	}
+}
+
+// CreateTables prepares the tables for the operator
+func (operator *HashTableOperator) CreateTables(tx *sql.Tx) (err error) {
+	_, err = tx.Exec(operator.createTableQuery)
+
+	return err
+}
+
+// Get returns the block hash of height
+func (operator *HashTableOperator) Get(height uint64, sdb *sql.DB, tx *sql.Tx) (interface{}, error) {
+	var val string
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(operator.hashQuery, util.Uint64ToInt64(height)).Scan(&val)
+	} else {
+		err = sdb.QueryRow(operator.hashQuery, util.Uint64ToInt64(height)).Scan(&val)
+	}
+	switch err {
+	case sql.ErrNoRows:
+		return hash.ZeroHash256, db.ErrNotExist
+	case nil:
+		return hash.HexStringToHash256(val)
+	default:
+		return hash.ZeroHash256, err
+	}
+}
+
+// Put writes block hash for height
+func (operator *HashTableOperator) Put(height uint64, value interface{}, tx *sql.Tx) error {
+	h, ok := value.(hash.Hash256)
+	if !ok {
+		return errors.Errorf("unexpected type %s", reflect.TypeOf(value))
+	}
+	_, err := tx.Exec(operator.insertHashQuery, util.Uint64ToInt64(height), hex.EncodeToString(h[:]))
+	return err
+}
+
+// TipHeight returns the tip height in the hash table
+func (operator *HashTableOperator) TipHeight(sdb *sql.DB, tx *sql.Tx) (uint64, error) {
+	var val sql.NullInt64
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(operator.tipHeightQuery).Scan(&val)
+	} else {
+		err = sdb.QueryRow(operator.tipHeightQuery).Scan(&val)
+	}
+	switch err {
+	case sql.ErrNoRows:
+		return 0, db.ErrNotExist
+	case nil:
+		if val.Valid {
+			return uint64(val.Int64), nil
+		}
+		return 0, db.ErrNotExist
+	default:
+		return 0, err
+	}
+}
+
+// Rollback deletes the block hashes at and above height
+func (operator *HashTableOperator) Rollback(height uint64, tx *sql.Tx) error {
+	_, err := tx.Exec(operator.rollbackQuery, util.Uint64ToInt64(height))
+	return err
+}
+
+// Verify checks whether the block hash of height is valid. A missing block hash is valid, because it is not archived
+// for the migrated polls.
+func (operator *HashTableOperator) Verify(height uint64, sdb *sql.DB) error {
+	_, err := operator.Get(height, sdb, nil)
+	switch errors.Cause(err) {
+	case nil, db.ErrNotExist:
+		return nil
+	default:
+		return errors.Wrapf(ErrInconsistent, "invalid block hash at height %d: %v", height, err)
+	}
+}
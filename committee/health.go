@@ -0,0 +1,147 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-election/carrier"
+)
+
+type (
+	// HealthConfig defines the thresholds of liveness and readiness
+	HealthConfig struct {
+		// LivenessThreshold is the max duration without storing any height
+		LivenessThreshold time.Duration `yaml:"livenessThreshold"`
+		// ReadinessLagThreshold is the max number of blocks the archive could fall behind the tip, twice the interval by
+		// default
+		ReadinessLagThreshold uint64 `yaml:"readinessLagThreshold"`
+	}
+
+	// Health defines the detailed health of a committee
+	Health struct {
+		Status STATUS
+		// Live is true unless nothing has been stored within the liveness threshold
+		Live bool
+		// Ready is true if the committee is active and the lag is within the readiness threshold
+		Ready          bool
+		ArchivedHeight uint64
+		TipHeight      uint64
+		LagBlocks      uint64
+		// LagTime is the time from the mint time of the archived height to when the tip was observed
+		LagTime       time.Duration
+		LastError     string
+		LastErrorTime time.Time
+		Endpoints     []carrier.EndpointStatus
+	}
+
+	// healthTracker records the tip, the last error, and the endpoints called directly by a committee
+	healthTracker struct {
+		mutex                 sync.RWMutex
+		livenessThreshold     time.Duration
+		readinessLagThreshold uint64
+		tipHeight             uint64
+		tipTime               time.Time
+		lastError             string
+		lastErrorTime         time.Time
+		endpoints             []carrier.EndpointStatus
+	}
+)
+
+func newHealthTracker(cfg HealthConfig, defaultLivenessThreshold time.Duration, interval uint64) *healthTracker {
+	t := &healthTracker{
+		livenessThreshold:     defaultLivenessThreshold,
+		readinessLagThreshold: 2 * interval,
+	}
+	if cfg.LivenessThreshold > 0 {
+		t.livenessThreshold = cfg.LivenessThreshold
+	}
+	if cfg.ReadinessLagThreshold > 0 {
+		t.readinessLagThreshold = cfg.ReadinessLagThreshold
+	}
+	return t
+}
+
+func (t *healthTracker) observeTip(height uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.tipHeight = height
+	t.tipTime = time.Now()
+}
+
+func (t *healthTracker) observeError(err error) {
+	if err == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.lastError = err.Error()
+	t.lastErrorTime = time.Now()
+}
+
+func (t *healthTracker) observeEndpoint(url string, err error) {
+	status := carrier.EndpointStatus{
+		URL:       url,
+		Reachable: err == nil,
+		LastCheck: time.Now(),
+	}
+	if err != nil {
+		status.LastError = err.Error()
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for i := range t.endpoints {
+		if t.endpoints[i].URL == url {
+			t.endpoints[i] = status
+			return
+		}
+	}
+	t.endpoints = append(t.endpoints, status)
+}
+
+func (t *healthTracker) status(lastUpdateTimestamp int64) STATUS {
+	switch {
+	case lastUpdateTimestamp == 0:
+		return STARTING
+	case lastUpdateTimestamp > time.Now().Add(-t.livenessThreshold).Unix():
+		return ACTIVE
+	default:
+		return INACTIVE
+	}
+}
+
+func (t *healthTracker) health(
+	lastUpdateTimestamp int64,
+	archivedHeight uint64,
+	archivedMintTime time.Time,
+	endpoints []carrier.EndpointStatus,
+) *Health {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	h := &Health{
+		Status:         t.status(lastUpdateTimestamp),
+		ArchivedHeight: archivedHeight,
+		TipHeight:      t.tipHeight,
+		LastError:      t.lastError,
+		LastErrorTime:  t.lastErrorTime,
+		Endpoints:      append(append([]carrier.EndpointStatus{}, endpoints...), t.endpoints...),
+	}
+	if t.tipHeight > archivedHeight {
+		h.LagBlocks = t.tipHeight - archivedHeight
+	}
+	if !archivedMintTime.IsZero() && t.tipTime.After(archivedMintTime) {
+		h.LagTime = t.tipTime.Sub(archivedMintTime)
+	}
+	h.Live = h.Status != INACTIVE
+	h.Ready = h.Status == ACTIVE && t.tipHeight != 0 && h.LagBlocks <= t.readinessLagThreshold
+	return h
+}
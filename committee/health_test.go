@@ -0,0 +1,69 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/carrier"
+)
+
+func TestHealthTracker(t *testing.T) {
+	require := require.New(t)
+	tracker := newHealthTracker(HealthConfig{}, 5*time.Minute, 10)
+	require.Equal(5*time.Minute, tracker.livenessThreshold)
+	require.Equal(uint64(20), tracker.readinessLagThreshold)
+
+	h := tracker.health(0, 0, time.Time{}, nil)
+	require.Equal(STARTING, h.Status)
+	require.True(h.Live)
+	require.False(h.Ready)
+
+	now := time.Now()
+	tracker.observeTip(150)
+	h = tracker.health(now.Unix(), 140, now.Add(-time.Minute), []carrier.EndpointStatus{{URL: "a", Reachable: true}})
+	require.Equal(ACTIVE, h.Status)
+	require.True(h.Live)
+	require.True(h.Ready)
+	require.Equal(uint64(10), h.LagBlocks)
+	require.True(h.LagTime >= time.Minute)
+	require.Equal(1, len(h.Endpoints))
+
+	h = tracker.health(now.Unix(), 120, now, nil)
+	require.Equal(uint64(30), h.LagBlocks)
+	require.True(h.Live)
+	require.False(h.Ready)
+
+	h = tracker.health(now.Add(-6*time.Minute).Unix(), 150, now, nil)
+	require.Equal(INACTIVE, h.Status)
+	require.False(h.Live)
+	require.False(h.Ready)
+
+	tracker.observeError(errors.New("oops"))
+	tracker.observeEndpoint("b", errors.New("unreachable"))
+	tracker.observeEndpoint("b", nil)
+	h = tracker.health(now.Unix(), 150, now, nil)
+	require.Equal("oops", h.LastError)
+	require.False(h.LastErrorTime.IsZero())
+	require.Equal(1, len(h.Endpoints))
+	require.Equal("b", h.Endpoints[0].URL)
+	require.True(h.Endpoints[0].Reachable)
+
+	tracker = newHealthTracker(HealthConfig{LivenessThreshold: time.Hour, ReadinessLagThreshold: 100}, 5*time.Minute, 10)
+	tracker.observeTip(150)
+	h = tracker.health(now.Add(-6*time.Minute).Unix(), 60, now, nil)
+	require.True(h.Live)
+	require.True(h.Ready)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import "sync"
+
+// heightNotifier notifies the subscribers of the lowest height changed, either stored or rolled back. A subscriber
+// channel only holds the lowest height changed since the last receive, such that a slow subscriber never blocks the
+// notifier, and catches up from that height instead.
+type heightNotifier struct {
+	mutex       sync.Mutex
+	nextID      uint64
+	subscribers map[uint64]chan uint64
+}
+
+func newHeightNotifier() *heightNotifier {
+	return &heightNotifier{subscribers: map[uint64]chan uint64{}}
+}
+
+func (n *heightNotifier) subscribe() (<-chan uint64, func()) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	id := n.nextID
+	n.nextID++
+	ch := make(chan uint64, 1)
+	n.subscribers[id] = ch
+	return ch, func() {
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		delete(n.subscribers, id)
+	}
+}
+
+func (n *heightNotifier) notify(height uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for _, ch := range n.subscribers {
+		select {
+		case ch <- height:
+			continue
+		default:
+		}
+		// merge with the pending height
+		pending := height
+		select {
+		case h := <-ch:
+			if h < pending {
+				pending = h
+			}
+		default:
+		}
+		select {
+		case ch <- pending:
+		default:
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeightNotifier(t *testing.T) {
+	require := require.New(t)
+	n := newHeightNotifier()
+	ch1, cancel1 := n.subscribe()
+	ch2, cancel2 := n.subscribe()
+	n.notify(130)
+	require.Equal(uint64(130), <-ch1)
+	// the pending heights are merged into the lowest one without blocking
+	n.notify(150)
+	n.notify(120)
+	n.notify(160)
+	require.Equal(uint64(120), <-ch1)
+	require.Equal(uint64(120), <-ch2)
+	cancel1()
+	n.notify(170)
+	require.Equal(0, len(ch1))
+	require.Equal(uint64(170), <-ch2)
+	cancel2()
+	require.Equal(0, len(n.subscribers))
+}
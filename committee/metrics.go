@@ -0,0 +1,94 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The committee label of the metrics
+const (
+	gravityCommitteeLabel = "gravity"
+	nativeCommitteeLabel  = "native"
+)
+
+var (
+	syncHeightMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_election_sync_height",
+			Help: "Tip height of the carrier and latest height in archive",
+		},
+		[]string{"committee", "type"},
+	)
+	syncLagMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_election_sync_lag",
+			Help: "Number of blocks the archive falls behind the carrier tip",
+		},
+		[]string{"committee"},
+	)
+	fetchInBatchDurationMtc = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iotex_election_fetch_in_batch_duration_seconds",
+			Help:    "Duration of fetching a batch of heights",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"committee"},
+	)
+	fetchRetryMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_election_fetch_retries_total",
+			Help: "Number of failed attempts to fetch the data of a height",
+		},
+		[]string{"committee"},
+	)
+	logRangeSplitMtc = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "iotex_election_log_range_splits_total",
+			Help: "Number of native staking log ranges split for exceeding the limits of the IoTeX API",
+		},
+	)
+	cacheLookupMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_election_cache_lookups_total",
+			Help: "Number of cache lookups by result, either hit or miss",
+		},
+		[]string{"cache", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(syncHeightMtc, syncLagMtc, fetchInBatchDurationMtc, fetchRetryMtc, logRangeSplitMtc, cacheLookupMtc)
+}
+
+func reportSyncHeights(committee string, tipHeight uint64, archivedHeight uint64) {
+	syncHeightMtc.WithLabelValues(committee, "tip").Set(float64(tipHeight))
+	syncHeightMtc.WithLabelValues(committee, "archived").Set(float64(archivedHeight))
+	lag := float64(0)
+	if tipHeight > archivedHeight {
+		lag = float64(tipHeight - archivedHeight)
+	}
+	syncLagMtc.WithLabelValues(committee).Set(lag)
+}
+
+func reportFetchInBatch(committee string, start time.Time) {
+	fetchInBatchDurationMtc.WithLabelValues(committee).Observe(time.Since(start).Seconds())
+}
+
+func reportCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookupMtc.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportMetrics(t *testing.T) {
+	require := require.New(t)
+	reportSyncHeights("test", 150, 120)
+	require.Equal(float64(150), testutil.ToFloat64(syncHeightMtc.WithLabelValues("test", "tip")))
+	require.Equal(float64(120), testutil.ToFloat64(syncHeightMtc.WithLabelValues("test", "archived")))
+	require.Equal(float64(30), testutil.ToFloat64(syncLagMtc.WithLabelValues("test")))
+	reportSyncHeights("test", 100, 120)
+	require.Equal(float64(0), testutil.ToFloat64(syncLagMtc.WithLabelValues("test")))
+
+	hits := testutil.ToFloat64(cacheLookupMtc.WithLabelValues("test", "hit"))
+	misses := testutil.ToFloat64(cacheLookupMtc.WithLabelValues("test", "miss"))
+	reportCacheLookup("test", true)
+	reportCacheLookup("test", true)
+	reportCacheLookup("test", false)
+	require.Equal(hits+2, testutil.ToFloat64(cacheLookupMtc.WithLabelValues("test", "hit")))
+	require.Equal(misses+1, testutil.ToFloat64(cacheLookupMtc.WithLabelValues("test", "miss")))
+}
@@ -12,7 +12,6 @@ package committee
 
 import (
 	"context"
-	"encoding/hex"
 	"math/big"
 	"sort"
 	"strings"
@@ -33,8 +32,11 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-election/contract"
+	"github.com/iotexproject/iotex-election/db"
 	"github.com/iotexproject/iotex-election/types"
 )
 
@@ -45,7 +47,10 @@ type (
 		interval        uint64
 		fetchInParallel uint8
 
-		cache *lru.Cache
+		cache        *lru.Cache
+		historyCache *lru.Cache
+		// rollbacks is the number of rollbacks purging the caches, guarded by mutex
+		rollbacks uint64
 
 		startHeight         uint64
 		currentHeight       uint64
@@ -58,13 +63,24 @@ type (
 		client                 iotexapi.APIServiceClient
 		stakingContractAddress address.Address
 		boundContract          *bind.BoundContract
+		stakingABI             abi.ABI
+		iotexAPI               string
+		health                 *healthTracker
+		notifier               *heightNotifier
+		confirmHeight          uint64
+		reorgCheckDepth        uint64
 	}
 
 	NativeCommitteeConfig struct {
-		StakingContractAddress string `yaml:"stakingContractAddress"`
-		IoTeXAPI               string `yaml:"iotexAPI"`
-		StartHeight            uint64 `yaml:"startHeight"`
-		Interval               uint64 `yaml:"interval"`
+		StakingContractAddress string       `yaml:"stakingContractAddress"`
+		IoTeXAPI               string       `yaml:"iotexAPI"`
+		StartHeight            uint64       `yaml:"startHeight"`
+		Interval               uint64       `yaml:"interval"`
+		Health                 HealthConfig `yaml:"health"`
+		// ConfirmHeight is the number of blocks behind the tip to sync to, 12 by default
+		ConfirmHeight uint64 `yaml:"confirmHeight"`
+		// ReorgCheckDepth is the number of the latest deltas to check against chain reorganization, 10 by default
+		ReorgCheckDepth uint64 `yaml:"reorgCheckDepth"`
 	}
 
 	pyggBucket struct {
@@ -77,6 +93,7 @@ type (
 		Owner     address.Address
 	}
 
+	// byIndex sorts the logs in the order they are emitted, i.e., by block height and then by index in block
 	byIndex struct {
 		logs []*iotextypes.Log
 	}
@@ -87,6 +104,9 @@ func (bi byIndex) Len() int {
 }
 
 func (bi byIndex) Less(i, j int) bool {
+	if bi.logs[i].BlkHeight != bi.logs[j].BlkHeight {
+		return bi.logs[i].BlkHeight < bi.logs[j].BlkHeight
+	}
 	return bi.logs[i].Index < bi.logs[j].Index
 }
 func (bi byIndex) Swap(i, j int) {
@@ -111,11 +131,10 @@ func (pb *pyggBucket) Hash() (hash.Hash256, error) {
 	return b.Hash()
 }
 
-// TODO: construct from abi
-var (
-	topicCreated = "d7812fae7f8126d2df0f5449a2cc0744d2e9d3fc8c161de6193bc4df6c68d365"
-	topicUpdated = "0b074423c8a0f26c131cd7c88b19ef6adf084b812c97bdd1fb9dcf339ee9a387"
-	topicUnstake = "9954bdedc474e937b39bbb080fc136e2edf1cef61f0906d36203267f4930762e"
+// The back-off of catching up after a failed round
+const (
+	catchUpMinBackoff = time.Second
+	catchUpMaxBackoff = time.Minute
 )
 
 func NewNativeStakingCommittee(
@@ -130,6 +149,10 @@ func NewNativeStakingCommittee(
 	if err != nil {
 		return nil, err
 	}
+	historyCache, err := lru.New(8192)
+	if err != nil {
+		return nil, err
+	}
 	addr, err := address.FromString(cfg.StakingContractAddress)
 	if err != nil {
 		return nil, err
@@ -138,10 +161,20 @@ func NewNativeStakingCommittee(
 	if err != nil {
 		return nil, err
 	}
+	confirmHeight := uint64(12)
+	if cfg.ConfirmHeight > 0 {
+		confirmHeight = cfg.ConfirmHeight
+	}
+	reorgCheckDepth := uint64(10)
+	if cfg.ReorgCheckDepth > 0 {
+		reorgCheckDepth = cfg.ReorgCheckDepth
+	}
 	return &NativeCommittee{
 		boundContract:          bind.NewBoundContract(common.BytesToAddress(addr.Bytes()), parsed, nil, nil, nil),
+		stakingABI:             parsed,
 		archive:                archive,
 		cache:                  cache,
+		historyCache:           historyCache,
 		client:                 iotexapi.NewAPIServiceClient(conn),
 		currentHeight:          0,
 		fetchInParallel:        uint8(10),
@@ -152,6 +185,11 @@ func NewNativeStakingCommittee(
 		startHeight:            cfg.StartHeight,
 		stakingContractAddress: addr,
 		terminate:              make(chan bool),
+		iotexAPI:               cfg.IoTeXAPI,
+		health:                 newHealthTracker(cfg.Health, 61*time.Minute, cfg.Interval),
+		notifier:               newHeightNotifier(),
+		confirmHeight:          confirmHeight,
+		reorgCheckDepth:        reorgCheckDepth,
 	}, nil
 }
 
@@ -167,15 +205,25 @@ func (nc *NativeCommittee) Start(ctx context.Context) error {
 	}
 	go func() {
 		zap.L().Info("catching up via network", zap.Uint64("tip", tip))
-		for h := nc.nextHeight(); h < tip; h += nc.interval * 10 {
+		// every delta fetched is stored right away, so a failed round only backs off, and resumes from the archive tip
+		backoff := catchUpMinBackoff
+		batch := nc.interval * (uint64(nc.fetchInParallel) - 1)
+		for h := nc.nextHeight() + batch; h < tip; h = nc.nextHeight() + batch {
 			zap.L().Info("catching up to", zap.Uint64("height", h))
 			if err := nc.sync(h); err != nil {
-				zap.L().Error("failed to fetch data", zap.Error(err))
+				nc.health.observeError(err)
+				zap.L().Error("failed to fetch data", zap.Error(err), zap.Duration("backoff", backoff))
+				time.Sleep(backoff)
+				if backoff *= 2; backoff > catchUpMaxBackoff {
+					backoff = catchUpMaxBackoff
+				}
+				continue
 			}
-			time.Sleep(10 * time.Second)
+			backoff = catchUpMinBackoff
 		}
 		zap.L().Info("catching up to tip", zap.Uint64("height", tip))
 		if err := nc.sync(tip); err != nil {
+			nc.health.observeError(err)
 			zap.L().Error("failed to fetch data", zap.Error(err))
 		}
 		ticker := time.NewTicker(nc.tickerDuration)
@@ -187,10 +235,12 @@ func (nc *NativeCommittee) Start(ctx context.Context) error {
 			case <-ticker.C:
 				tip, err := nc.tip()
 				if err != nil {
+					nc.health.observeError(err)
 					zap.L().Error("failed to get tip", zap.Error(err))
 					break
 				}
 				if err := nc.sync(tip); err != nil {
+					nc.health.observeError(err)
 					zap.L().Error("failed to sync", zap.Error(err))
 				}
 			}
@@ -207,7 +257,8 @@ func (nc *NativeCommittee) Stop(ctx context.Context) error {
 	return nc.archive.Stop(ctx)
 }
 
-func (nc *NativeCommittee) mintTime(height uint64) (time.Time, error) {
+// blockMeta returns the mint time and the hash of the block of height
+func (nc *NativeCommittee) blockMeta(height uint64) (time.Time, hash.Hash256, error) {
 	response, err := nc.client.GetBlockMetas(
 		context.Background(),
 		&iotexapi.GetBlockMetasRequest{
@@ -220,14 +271,25 @@ func (nc *NativeCommittee) mintTime(height uint64) (time.Time, error) {
 		},
 	)
 	if err != nil {
-		return time.Time{}, err
+		return time.Time{}, hash.ZeroHash256, err
+	}
+	if len(response.BlkMetas) == 0 {
+		return time.Time{}, hash.ZeroHash256, errors.Errorf("no block meta of height %d", height)
+	}
+	mintTime, err := ptypes.Timestamp(response.BlkMetas[0].Timestamp)
+	if err != nil {
+		return time.Time{}, hash.ZeroHash256, err
 	}
-	return ptypes.Timestamp(response.BlkMetas[0].Timestamp)
+	blockHash, err := hash.HexStringToHash256(response.BlkMetas[0].Hash)
+	if err != nil {
+		return time.Time{}, hash.ZeroHash256, err
+	}
+	return mintTime, blockHash, nil
 }
 
 func (nc *NativeCommittee) fetchDataByHeight(height uint64) (*timeAndBuckets, error) {
 	zap.L().Info("fetch data", zap.Uint64("from", height), zap.Uint64("to", height+nc.interval-1))
-	mintTime, err := nc.mintTime(height + nc.interval - 1)
+	mintTime, blockHash, err := nc.blockMeta(height + nc.interval - 1)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +299,9 @@ func (nc *NativeCommittee) fetchDataByHeight(height uint64) (*timeAndBuckets, er
 	}
 
 	return &timeAndBuckets{
-		mintTime: mintTime,
-		buckets:  buckets,
+		mintTime:  mintTime,
+		blockHash: blockHash,
+		buckets:   buckets,
 	}, nil
 }
 
@@ -247,6 +310,7 @@ func (nc *NativeCommittee) retryFetchDataByHeight(height uint64) (data *timeAndB
 		if data, err = nc.fetchDataByHeight(height); err == nil {
 			break
 		}
+		fetchRetryMtc.WithLabelValues(nativeCommitteeLabel).Inc()
 		zap.L().Error(
 			"failed to fetch result by height",
 			zap.Error(err),
@@ -258,11 +322,13 @@ func (nc *NativeCommittee) retryFetchDataByHeight(height uint64) (data *timeAndB
 }
 
 type timeAndBuckets struct {
-	mintTime time.Time
-	buckets  []*pyggBucket
+	mintTime  time.Time
+	blockHash hash.Hash256
+	buckets   []*pyggBucket
 }
 
 func (nc *NativeCommittee) fetchInBatch(tipHeight uint64) (retval map[uint64]*timeAndBuckets, err error) {
+	defer reportFetchInBatch(nativeCommitteeLabel, time.Now())
 	if nc.currentHeight < tipHeight {
 		nc.currentHeight = tipHeight
 	}
@@ -281,10 +347,11 @@ func (nc *NativeCommittee) fetchInBatch(tipHeight uint64) (retval map[uint64]*ti
 			data, e := nc.retryFetchDataByHeight(height - nc.interval + 1)
 			lock.Lock()
 			defer lock.Unlock()
-			retval[height] = data
 			if e != nil {
 				err = e
+				return
 			}
+			retval[height] = data
 		}(nextHeight)
 	}
 	wg.Wait()
@@ -295,10 +362,12 @@ func (nc *NativeCommittee) fetchInBatch(tipHeight uint64) (retval map[uint64]*ti
 func (nc *NativeCommittee) storeInBatch(data map[uint64]*timeAndBuckets) error {
 	heights := make([]uint64, 0, len(data))
 	mintTimes := make([]time.Time, 0, len(data))
+	blockHashes := make([]hash.Hash256, 0, len(data))
 	arrOfBuckets := make([][]*pyggBucket, 0, len(data))
 	for height := range data {
 		heights = append(heights, height)
 		mintTimes = append(mintTimes, data[height].mintTime)
+		blockHashes = append(blockHashes, data[height].blockHash)
 		arrOfBuckets = append(arrOfBuckets, data[height].buckets)
 	}
 	indice := map[uint64]int{}
@@ -311,28 +380,95 @@ func (nc *NativeCommittee) storeInBatch(data map[uint64]*timeAndBuckets) error {
 	sort.Slice(heights, func(i, j int) bool {
 		return heights[i] < heights[j]
 	})
+	stored := 0
+	defer func() {
+		if stored == 0 {
+			return
+		}
+		atomic.StoreInt64(&nc.lastUpdateTimestamp, time.Now().Unix())
+		reportSyncHeights(nativeCommitteeLabel, nc.currentHeight, nc.latestHeightInArchive())
+		nc.notifier.notify(heights[0])
+	}()
 	for _, height := range heights {
+		// a delta is applied on top of the previous one, so the heights after a gap are dropped to be refetched
+		if height != nc.nextHeight() {
+			break
+		}
 		index := indice[height]
-		if err := nc.archive.PutDelta(height, mintTimes[index], arrOfBuckets[index]); err != nil {
+		if err := nc.archive.PutDelta(height, mintTimes[index], blockHashes[index], arrOfBuckets[index]); err != nil {
 			return err
 		}
+		stored++
 	}
-	atomic.StoreInt64(&nc.lastUpdateTimestamp, time.Now().Unix())
 	return nil
 }
 
 func (nc *NativeCommittee) sync(tipHeight uint64) error {
-	data, err := nc.fetchInBatch(tipHeight)
-	if err != nil {
-		return err
+	if err := nc.checkReorg(); err != nil {
+		return errors.Wrap(err, "failed to check chain reorganization")
 	}
+	// the deltas fetched are stored as checkpoints even if some others fail
+	data, fetchErr := nc.fetchInBatch(tipHeight)
 	if len(data) == 0 {
+		reportSyncHeights(nativeCommitteeLabel, nc.currentHeight, nc.latestHeightInArchive())
+		return fetchErr
+	}
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+	if err := nc.storeInBatch(data); err != nil {
+		return err
+	}
+
+	return fetchErr
+}
+
+// checkReorg compares the hashes of the blocks which the most recent deltas end at with the ones on IoTeX, and rolls
+// back the deltas from the lowest mismatched height, such that they will be refetched
+func (nc *NativeCommittee) checkReorg() error {
+	tipHeight := nc.latestHeightInArchive()
+	if tipHeight == 0 {
+		return nil
+	}
+	rollbackHeight := uint64(0)
+	height := tipHeight
+scan:
+	for i := uint64(0); i < nc.reorgCheckDepth && height >= nc.startHeight; i++ {
+		stored, err := nc.archive.BlockHash(height)
+		switch errors.Cause(err) {
+		case nil:
+			_, h, err := nc.blockMeta(height)
+			if err != nil {
+				return err
+			}
+			if h == stored {
+				break scan
+			}
+			rollbackHeight = height
+		case db.ErrNotExist:
+			// skip the deltas archived without block hash
+		default:
+			return err
+		}
+		if height < nc.startHeight+nc.interval {
+			break
+		}
+		height -= nc.interval
+	}
+	if rollbackHeight == 0 {
 		return nil
 	}
+	zap.L().Warn("chain reorganization detected", zap.Uint64("height", rollbackHeight))
 	nc.mutex.Lock()
 	defer nc.mutex.Unlock()
+	if err := nc.archive.Rollback(rollbackHeight); err != nil {
+		return err
+	}
+	nc.cache.Purge()
+	nc.historyCache.Purge()
+	nc.rollbacks++
+	nc.notifier.notify(rollbackHeight)
 
-	return nc.storeInBatch(data)
+	return nil
 }
 
 func (nc *NativeCommittee) DataByHeight(height uint64) (time.Time, []*types.Bucket, error) {
@@ -375,15 +511,22 @@ func (nc *NativeCommittee) TipHeight() uint64 {
 }
 
 func (nc *NativeCommittee) Status() STATUS {
-	lastUpdateTime := atomic.LoadInt64(&nc.lastUpdateTimestamp)
-	switch {
-	case lastUpdateTime == 0:
-		return STARTING
-	case lastUpdateTime > time.Now().Add(-61*time.Minute).Unix():
-		return ACTIVE
-	default:
-		return INACTIVE
+	return nc.health.status(atomic.LoadInt64(&nc.lastUpdateTimestamp))
+}
+
+// Health returns the detailed health of the committee
+func (nc *NativeCommittee) Health() *Health {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	archivedHeight := nc.latestHeightInArchive()
+	var mintTime time.Time
+	if archivedHeight != 0 {
+		var err error
+		if mintTime, err = nc.archive.MintTime(archivedHeight); err != nil {
+			zap.L().Error("failed to get mint time", zap.Uint64("height", archivedHeight), zap.Error(err))
+		}
 	}
+	return nc.health.health(atomic.LoadInt64(&nc.lastUpdateTimestamp), archivedHeight, mintTime, nil)
 }
 
 func (nc *NativeCommittee) tip() (tip uint64, err error) {
@@ -391,92 +534,149 @@ func (nc *NativeCommittee) tip() (tip uint64, err error) {
 		context.Background(),
 		&iotexapi.GetChainMetaRequest{},
 	)
+	nc.health.observeEndpoint(nc.iotexAPI, err)
 	if err != nil {
 		return 0, err
 	}
+	// only the blocks deep enough are synced, as the latest ones could be replaced
+	if response.ChainMeta.Height > nc.confirmHeight {
+		tip = response.ChainMeta.Height - nc.confirmHeight
+	}
+	nc.health.observeTip(tip)
 
-	return response.ChainMeta.Height, nil
+	return tip, nil
 }
 
 func (nc *NativeCommittee) delta(
 	from uint64,
 	count uint64,
 ) ([]*pyggBucket, error) {
-	topics := make([][]byte, 0)
-	if t, err := hex.DecodeString(topicCreated); err == nil {
-		topics = append(topics, t)
-	} else {
-		return nil, err
-	}
-	if t, err := hex.DecodeString(topicUpdated); err == nil {
-		topics = append(topics, t)
-	} else {
-		return nil, err
-	}
-	if t, err := hex.DecodeString(topicUnstake); err == nil {
-		topics = append(topics, t)
-	} else {
+	logs, err := nc.logs(from, from+count-1, nc.topics())
+	if err != nil {
 		return nil, err
 	}
+
+	return nc.bucketsOfLogs(logs)
+}
+
+// logs returns the logs of the staking contract in [from, to]. A range exceeding the limits of the IoTeX API is split
+// into halves, whose logs are merged.
+func (nc *NativeCommittee) logs(from uint64, to uint64, topics [][]byte) ([]*iotextypes.Log, error) {
 	response, err := nc.client.GetLogs(context.Background(), &iotexapi.GetLogsRequest{
 		Filter: &iotexapi.LogsFilter{
 			Address: []string{nc.stakingContractAddress.String()},
-			Topics:  []*iotexapi.Topics{&iotexapi.Topics{Topic: topics}},
+			Topics:  []*iotexapi.Topics{{Topic: topics}},
 		},
 		Lookup: &iotexapi.GetLogsRequest_ByRange{
 			ByRange: &iotexapi.GetLogsByRange{
 				FromBlock: from,
-				ToBlock:   from + count - 1,
+				ToBlock:   to,
 			},
 		},
 	})
+	if err == nil {
+		return response.Logs, nil
+	}
+	if from == to || !isLogLimitError(err) {
+		return nil, errors.Wrapf(err, "failed to get logs in [%d, %d]", from, to)
+	}
+	logRangeSplitMtc.Inc()
+	mid := from + (to-from)/2
+	zap.L().Debug("split log range", zap.Uint64("from", from), zap.Uint64("to", to), zap.Uint64("mid", mid))
+	lower, err := nc.logs(from, mid, topics)
 	if err != nil {
 		return nil, err
 	}
+	upper, err := nc.logs(mid+1, to, topics)
+	if err != nil {
+		return nil, err
+	}
+	logs := append(lower, upper...)
+	sort.Sort(byIndex{logs})
+
+	return logs, nil
+}
+
+// isLogLimitError returns true if err is returned by the IoTeX API for too many blocks or logs in a query
+func isLogLimitError(err error) bool {
+	s, ok := status.FromError(errors.Cause(err))
+	if !ok {
+		return false
+	}
+	switch s.Code() {
+	case codes.ResourceExhausted:
+		return true
+	case codes.InvalidArgument, codes.Unknown, codes.Internal:
+		msg := strings.ToLower(s.Message())
+		return strings.Contains(msg, "limit") || strings.Contains(msg, "too many") || strings.Contains(msg, "too large")
+	default:
+		return false
+	}
+}
 
-	buckets := make([]*pyggBucket, 0)
-	sort.Sort(byIndex{response.Logs})
-	// update buckets correspondingly
-	for _, l := range response.Logs {
-		bucket := &pyggBucket{}
+// pyggEvents are the events of the staking contract replayed into the buckets
+var pyggEvents = []string{"PyggCreated", "PyggUpdated", "PyggUnstake", "PyggWithdraw"}
+
+// topics returns the ids of the events replayed into the buckets
+func (nc *NativeCommittee) topics() [][]byte {
+	topics := make([][]byte, 0, len(pyggEvents))
+	for _, name := range pyggEvents {
+		topics = append(topics, nc.stakingABI.Events[name].ID.Bytes())
+	}
+	return topics
+}
+
+// bucketsOfLogs replays the logs of the staking contract, and returns the final state of each bucket updated, sorted
+// by index. A bucket unstaked or withdrawn is returned with nil amount.
+func (nc *NativeCommittee) bucketsOfLogs(logs []*iotextypes.Log) ([]*pyggBucket, error) {
+	sort.Sort(byIndex{logs})
+	updated := map[uint64]*pyggBucket{}
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			return nil, errors.New("log without topic")
+		}
+		event, err := nc.stakingABI.EventByID(common.BytesToHash(l.Topics[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid topic %x", l.Topics[0])
+		}
 		etherLog := toEtherLog(l)
-		switch hex.EncodeToString(l.Topics[0]) {
-		case topicCreated:
-			event := new(contract.PyggStakingPyggCreated)
-			if err := nc.boundContract.UnpackLog(event, "PyggCreated", etherLog); err != nil {
+		var bucket *pyggBucket
+		switch event.Name {
+		case "PyggCreated", "PyggUpdated":
+			// the events share the same fields, and an ownership transfer is emitted as an update
+			e := new(contract.PyggStakingPyggUpdated)
+			if err := nc.boundContract.UnpackLog(e, event.Name, etherLog); err != nil {
 				return nil, err
 			}
-			bucket.Index = event.PyggIndex.Uint64()
-			bucket.CanName = event.CanName
-			bucket.StartTime = time.Unix(event.StakeStartTime.Int64(), 0)
-			bucket.Decay = !event.NonDecay
-			bucket.Duration = time.Duration(event.StakeDuration.Uint64()*24) * time.Hour
-			bucket.Amount = event.Amount
-			bucket.Owner = event.PyggOwner
-		case topicUpdated:
-			event := new(contract.PyggStakingPyggUpdated)
-			if err := nc.boundContract.UnpackLog(event, "PyggUpdated", etherLog); err != nil {
-				return nil, err
+			bucket = &pyggBucket{
+				Index:     e.PyggIndex.Uint64(),
+				CanName:   e.CanName,
+				StartTime: time.Unix(e.StakeStartTime.Int64(), 0),
+				Decay:     !e.NonDecay,
+				Duration:  time.Duration(e.StakeDuration.Uint64()*24) * time.Hour,
+				Amount:    e.Amount,
+				Owner:     e.PyggOwner,
 			}
-			bucket.Index = event.PyggIndex.Uint64()
-			bucket.CanName = event.CanName
-			bucket.StartTime = time.Unix(event.StakeStartTime.Int64(), 0)
-			bucket.Decay = !event.NonDecay
-			bucket.Duration = time.Duration(event.StakeDuration.Uint64()*24) * time.Hour
-			bucket.Amount = event.Amount
-			bucket.Owner = event.PyggOwner
-		case topicUnstake:
-			event := new(contract.PyggStakingPyggUnstake)
-			if err := nc.boundContract.UnpackLog(event, "PyggUnstake", etherLog); err != nil {
+		case "PyggUnstake", "PyggWithdraw":
+			// an unstaked bucket no longer votes, and a withdrawn one is deleted
+			e := new(contract.PyggStakingPyggWithdraw)
+			if err := nc.boundContract.UnpackLog(e, event.Name, etherLog); err != nil {
 				return nil, err
 			}
-			bucket.Index = event.PyggIndex.Uint64()
-			bucket.Amount = nil
+			bucket = &pyggBucket{Index: e.PyggIndex.Uint64(), Amount: nil}
 		default:
-			return nil, errors.Errorf("Invalid topic %x", l.Topics[0])
+			// whitelist and pause events don't change any bucket
+			continue
 		}
+		updated[bucket.Index] = bucket
+	}
+	buckets := make([]*pyggBucket, 0, len(updated))
+	for _, bucket := range updated {
 		buckets = append(buckets, bucket)
 	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Index < buckets[j].Index
+	})
 
 	return buckets, nil
 }
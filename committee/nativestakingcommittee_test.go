@@ -0,0 +1,306 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/ptypes"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-election/contract"
+	"github.com/iotexproject/iotex-election/db"
+)
+
+type fakeIoTeXClient struct {
+	iotexapi.APIServiceClient
+	height uint64
+	hashes map[uint64]hash.Hash256
+	// logs are returned by GetLogs for ranges of no more than maxRange blocks
+	logs     []*iotextypes.Log
+	maxRange uint64
+	calls    int
+}
+
+func (fc *fakeIoTeXClient) GetLogs(
+	ctx context.Context,
+	in *iotexapi.GetLogsRequest,
+	opts ...grpc.CallOption,
+) (*iotexapi.GetLogsResponse, error) {
+	fc.calls++
+	from, to := in.GetByRange().FromBlock, in.GetByRange().ToBlock
+	if to-from+1 > fc.maxRange {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	logs := []*iotextypes.Log{}
+	for _, l := range fc.logs {
+		if l.BlkHeight >= from && l.BlkHeight <= to {
+			logs = append(logs, l)
+		}
+	}
+	return &iotexapi.GetLogsResponse{Logs: logs}, nil
+}
+
+func (fc *fakeIoTeXClient) GetChainMeta(
+	ctx context.Context,
+	in *iotexapi.GetChainMetaRequest,
+	opts ...grpc.CallOption,
+) (*iotexapi.GetChainMetaResponse, error) {
+	return &iotexapi.GetChainMetaResponse{ChainMeta: &iotextypes.ChainMeta{Height: fc.height}}, nil
+}
+
+func (fc *fakeIoTeXClient) GetBlockMetas(
+	ctx context.Context,
+	in *iotexapi.GetBlockMetasRequest,
+	opts ...grpc.CallOption,
+) (*iotexapi.GetBlockMetasResponse, error) {
+	height := in.GetByIndex().Start
+	h, ok := fc.hashes[height]
+	if !ok {
+		return nil, errors.Errorf("no block %d", height)
+	}
+	ts, err := ptypes.TimestampProto(time.Unix(int64(1600000000+height*5), 0))
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetBlockMetasResponse{
+		BlkMetas: []*iotextypes.BlockMeta{{Hash: hex.EncodeToString(h[:]), Height: height, Timestamp: ts}},
+	}, nil
+}
+
+func TestNativeCommitteeReorg(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	arch, err := NewBucketArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "native.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	defer func() {
+		require.NoError(arch.Stop(ctx))
+	}()
+	cache, err := lru.New(10)
+	require.NoError(err)
+	historyCache, err := lru.New(10)
+	require.NoError(err)
+	client := &fakeIoTeXClient{height: 200, hashes: map[uint64]hash.Hash256{}}
+	nc := &NativeCommittee{
+		archive:         arch,
+		cache:           cache,
+		historyCache:    historyCache,
+		client:          client,
+		startHeight:     100,
+		interval:        10,
+		confirmHeight:   5,
+		reorgCheckDepth: 10,
+		health:          newHealthTracker(HealthConfig{}, time.Hour, 10),
+		notifier:        newHeightNotifier(),
+	}
+
+	// the tip is behind the chain by the confirmation depth
+	tip, err := nc.tip()
+	require.NoError(err)
+	require.Equal(uint64(195), tip)
+
+	owner, err := address.FromBytes(make([]byte, 20))
+	require.NoError(err)
+	for _, height := range []uint64{100, 110, 120} {
+		client.hashes[height] = hash.Hash256b([]byte{byte(height)})
+		require.NoError(arch.PutDelta(height, time.Unix(int64(1600000000+height*5), 0), client.hashes[height], []*pyggBucket{
+			{Index: height, CanName: [12]byte{11: 'a'}, Amount: big.NewInt(int64(height)), StartTime: time.Unix(0, 0), Owner: owner},
+		}))
+	}
+	_, err = nc.ResultByHeight(120)
+	require.NoError(err)
+	require.Equal(1, nc.cache.Len())
+	heights, cancel := nc.SubscribeHeights()
+	defer cancel()
+
+	// no reorganization
+	require.NoError(nc.checkReorg())
+	require.Equal(uint64(120), nc.TipHeight())
+
+	// the blocks which the deltas of 110 and 120 end at are replaced
+	client.hashes[110] = hash.Hash256b([]byte("replaced 110"))
+	client.hashes[120] = hash.Hash256b([]byte("replaced 120"))
+	require.NoError(nc.checkReorg())
+	require.Equal(uint64(100), nc.TipHeight())
+	require.Equal(0, nc.cache.Len())
+	_, err = arch.BlockHash(110)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	stored, err := arch.BlockHash(100)
+	require.NoError(err)
+	require.Equal(client.hashes[100], stored)
+	buckets, err := arch.Buckets(100)
+	require.NoError(err)
+	require.Equal(1, len(buckets))
+	select {
+	case height := <-heights:
+		require.Equal(uint64(110), height)
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout")
+	}
+
+	// the replaced deltas are refetched
+	require.NoError(arch.PutDelta(110, time.Unix(1600000550, 0), client.hashes[110], []*pyggBucket{
+		{Index: 100, Amount: nil},
+	}))
+	buckets, err = arch.Buckets(110)
+	require.NoError(err)
+	require.Equal(0, len(buckets))
+	require.NoError(nc.checkReorg())
+	require.Equal(uint64(110), nc.TipHeight())
+}
+
+func TestNativeCommitteeBucketsOfLogs(t *testing.T) {
+	require := require.New(t)
+	parsed, err := abi.JSON(strings.NewReader(contract.PyggStakingABI))
+	require.NoError(err)
+	nc := &NativeCommittee{
+		boundContract: bind.NewBoundContract(common.Address{}, parsed, nil, nil, nil),
+		stakingABI:    parsed,
+	}
+	// only the events changing the buckets are queried
+	topics := nc.topics()
+	require.Equal(4, len(topics))
+	for _, name := range []string{"PyggCreated", "PyggUpdated", "PyggUnstake", "PyggWithdraw"} {
+		require.Contains(topics, parsed.Events[name].ID.Bytes())
+	}
+	require.NotContains(topics, parsed.Events["Pause"].ID.Bytes())
+
+	toLog := func(height uint64, index uint32, name string, args ...interface{}) *iotextypes.Log {
+		data, err := parsed.Events[name].Inputs.Pack(args...)
+		require.NoError(err)
+		return &iotextypes.Log{
+			Topics:    [][]byte{parsed.Events[name].ID.Bytes()},
+			Data:      data,
+			BlkHeight: height,
+			Index:     index,
+		}
+	}
+	owner1 := common.BytesToAddress([]byte{1})
+	owner2 := common.BytesToAddress([]byte{2})
+	canName := [12]byte{11: 'a'}
+	amount := big.NewInt(100)
+	duration := big.NewInt(7)
+	start := big.NewInt(1600000000)
+	buckets, err := nc.bucketsOfLogs([]*iotextypes.Log{
+		// the ownership of bucket 0 is transferred in a later block with a smaller index
+		toLog(102, 0, "PyggUpdated", big.NewInt(0), canName, amount, duration, start, false, owner2, []byte{}),
+		toLog(101, 3, "PyggCreated", big.NewInt(0), canName, amount, duration, start, false, owner1, []byte{}),
+		toLog(101, 4, "PyggCreated", big.NewInt(1), canName, amount, duration, start, true, owner1, []byte{}),
+		// the other events are skipped if ever returned
+		toLog(101, 5, "WhitelistedAddressAdded", owner1),
+		toLog(101, 6, "Pause"),
+		toLog(103, 0, "PyggUnstake", big.NewInt(1), canName, amount, []byte{}),
+		toLog(104, 0, "PyggWithdraw", big.NewInt(2), canName, amount, []byte{}),
+	})
+	require.NoError(err)
+	require.Equal(3, len(buckets))
+	require.Equal(uint64(0), buckets[0].Index)
+	require.Equal(owner2.Bytes(), buckets[0].Owner.Bytes())
+	require.Equal(0, amount.Cmp(buckets[0].Amount))
+	require.Equal(7*24*time.Hour, buckets[0].Duration)
+	require.True(buckets[0].Decay)
+	require.Equal(uint64(1), buckets[1].Index)
+	require.Nil(buckets[1].Amount)
+	require.Equal(uint64(2), buckets[2].Index)
+	require.Nil(buckets[2].Amount)
+
+	_, err = nc.bucketsOfLogs([]*iotextypes.Log{{Topics: [][]byte{make([]byte, 32)}}})
+	require.Error(err)
+}
+
+func TestNativeCommitteeSplitLogRange(t *testing.T) {
+	require := require.New(t)
+	parsed, err := abi.JSON(strings.NewReader(contract.PyggStakingABI))
+	require.NoError(err)
+	owner, err := address.FromBytes(make([]byte, 20))
+	require.NoError(err)
+	client := &fakeIoTeXClient{maxRange: 3}
+	nc := &NativeCommittee{
+		boundContract:          bind.NewBoundContract(common.Address{}, parsed, nil, nil, nil),
+		stakingABI:             parsed,
+		client:                 client,
+		stakingContractAddress: owner,
+	}
+	canName := [12]byte{11: 'a'}
+	for i, height := range []uint64{100, 104, 109} {
+		data, err := parsed.Events["PyggCreated"].Inputs.Pack(
+			big.NewInt(int64(i)), canName, big.NewInt(100), big.NewInt(7), big.NewInt(1600000000), false, common.Address{}, []byte{},
+		)
+		require.NoError(err)
+		client.logs = append(client.logs, &iotextypes.Log{
+			Topics:    [][]byte{parsed.Events["PyggCreated"].ID.Bytes()},
+			Data:      data,
+			BlkHeight: height,
+		})
+	}
+
+	// [100, 109] is split into [100, 102], [103, 104], [105, 107] and [108, 109]
+	buckets, err := nc.delta(100, 10)
+	require.NoError(err)
+	require.Equal(7, client.calls)
+	require.Equal(3, len(buckets))
+	for i, bucket := range buckets {
+		require.Equal(uint64(i), bucket.Index)
+	}
+
+	// a single block exceeding the limits could not be split
+	client.maxRange = 0
+	_, err = nc.delta(100, 10)
+	require.Error(err)
+	require.False(isLogLimitError(errors.New("limit")))
+	require.True(isLogLimitError(errors.Wrap(status.Error(codes.ResourceExhausted, ""), "failed")))
+}
+
+func TestNativeCommitteeStoreCheckpoints(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	arch, err := NewBucketArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "native.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	defer func() {
+		require.NoError(arch.Stop(ctx))
+	}()
+	nc := &NativeCommittee{
+		archive:     arch,
+		startHeight: 100,
+		interval:    10,
+		notifier:    newHeightNotifier(),
+	}
+	data := func(height uint64) *timeAndBuckets {
+		return &timeAndBuckets{mintTime: time.Unix(int64(1600000000+height*5), 0), blockHash: hash.Hash256b([]byte{byte(height)})}
+	}
+
+	// the fetch of 120 failed, so the deltas after it are dropped
+	require.NoError(nc.storeInBatch(map[uint64]*timeAndBuckets{100: data(100), 110: data(110), 130: data(130)}))
+	require.Equal(uint64(110), nc.TipHeight())
+	require.Equal(uint64(120), nc.nextHeight())
+	require.NoError(nc.storeInBatch(map[uint64]*timeAndBuckets{120: data(120), 130: data(130)}))
+	require.Equal(uint64(130), nc.TipHeight())
+}
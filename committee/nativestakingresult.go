@@ -0,0 +1,215 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-election/util"
+)
+
+// ResultByHeight returns the ranking of the candidates voted by the native staking buckets at height. There is no
+// registration on IoTeX, so every candidate voted is ranked, and none of them has self staking tokens.
+func (nc *NativeCommittee) ResultByHeight(height uint64) (*types.ElectionResult, error) {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	return nc.resultByHeight(height)
+}
+
+func (nc *NativeCommittee) resultByHeight(height uint64) (*types.ElectionResult, error) {
+	if err := nc.validateHeight(height); err != nil {
+		return nil, err
+	}
+	value, ok := nc.cache.Get(height)
+	reportCacheLookup("native_result", ok)
+	if ok {
+		if result, as := value.(*types.ElectionResult); as {
+			return result, nil
+		}
+		return nil, errors.New("lru cache type assertion has error")
+	}
+	result, err := nc.calculateResult(height)
+	if err != nil {
+		return nil, err
+	}
+	nc.cache.Add(height, result)
+
+	return result, nil
+}
+
+func (nc *NativeCommittee) validateHeight(height uint64) error {
+	if height < nc.startHeight {
+		return errors.Errorf("height %d is lower than start height %d", height, nc.startHeight)
+	}
+	if (height-nc.startHeight)%nc.interval != 0 {
+		return errors.Errorf("height %d is an invalid height", height)
+	}
+	if height > nc.latestHeightInArchive() {
+		return errors.Errorf("height %d is higher than tip height", height)
+	}
+	return nil
+}
+
+func (nc *NativeCommittee) calculateResult(height uint64) (*types.ElectionResult, error) {
+	calculate, err := nc.loadResult(height)
+	if err != nil {
+		return nil, err
+	}
+	return calculate()
+}
+
+// loadResult reads the buckets of height from DB, and returns the calculation of the result from them
+func (nc *NativeCommittee) loadResult(height uint64) (func() (*types.ElectionResult, error), error) {
+	mintTime, err := nc.archive.MintTime(height)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := nc.archive.Buckets(height)
+	if err != nil {
+		return nil, err
+	}
+	return func() (*types.ElectionResult, error) {
+		calculator := types.NewResultCalculator(
+			mintTime,
+			false,
+			func(*types.Bucket) bool { return false },
+			types.CalcWeightedVotes,
+			func(*types.Candidate) bool { return false },
+		)
+		if err := calculator.AddRegistrations(registrationsOfBuckets(buckets)); err != nil {
+			return nil, err
+		}
+		if err := calculator.AddBuckets(buckets); err != nil {
+			return nil, err
+		}
+		return calculator.Calculate()
+	}, nil
+}
+
+// registrationsOfBuckets returns the registrations of the candidates voted by the buckets, sorted by name
+func registrationsOfBuckets(buckets []*types.Bucket) []*types.Registration {
+	names := map[string][]byte{}
+	for _, bucket := range buckets {
+		name := bucket.Candidate()
+		if util.IsAllZeros(name) {
+			continue
+		}
+		names[hex.EncodeToString(name)] = name
+	}
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	regs := make([]*types.Registration, 0, len(keys))
+	for _, key := range keys {
+		regs = append(regs, types.NewRegistration(names[key], nil, nil, nil, 1))
+	}
+	return regs
+}
+
+// LatestHeight returns the height with latest result
+func (nc *NativeCommittee) LatestHeight() uint64 {
+	return nc.TipHeight()
+}
+
+// RawDataByHeight returns the buckets and the mint time of height, with no registration
+func (nc *NativeCommittee) RawDataByHeight(height uint64) ([]*types.Bucket, []*types.Registration, time.Time, error) {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	if err := nc.validateHeight(height); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	mintTime, err := nc.archive.MintTime(height)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	buckets, err := nc.archive.Buckets(height)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	return buckets, []*types.Registration{}, mintTime, nil
+}
+
+// ScoreOverrides returns nil, as no score override is applied on the native staking results
+func (nc *NativeCommittee) ScoreOverrides() []ScoreOverride {
+	return nil
+}
+
+// HeightByTime returns the last height whose mint time is no later than ts
+func (nc *NativeCommittee) HeightByTime(ts time.Time) (uint64, error) {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	return nc.archive.HeightBefore(ts)
+}
+
+// CandidateHistory returns the stats of a candidate at every step heights in [fromHeight, toHeight]. A step of 0 stands
+// for the interval.
+func (nc *NativeCommittee) CandidateHistory(name []byte, fromHeight uint64, toHeight uint64, step uint64) ([]*CandidatePoint, error) {
+	nc.mutex.RLock()
+	heights, err := historyHeights(nc.startHeight, nc.interval, nc.latestHeightInArchive(), fromHeight, toHeight, step)
+	nc.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return candidatePoints(name, heights, func(height uint64) (*candidateSummary, error) {
+		return cachedCandidateSummary(nc.historyCache, nc.cache, nc.mutex.RLocker(), &nc.rollbacks, height, nc.loadResult)
+	})
+}
+
+// VoterHistory returns the votes of a voter at the heights in [fromHeight, toHeight] sorted by height, skipping the
+// heights without vote. There is no voter index of the native staking buckets, so the range could cover no more than
+// MaxCandidateHistoryPoints heights.
+func (nc *NativeCommittee) VoterHistory(voter []byte, fromHeight uint64, toHeight uint64) ([]*VoterPoll, error) {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	heights, err := historyHeights(nc.startHeight, nc.interval, nc.latestHeightInArchive(), fromHeight, toHeight, 0)
+	if err != nil {
+		return nil, err
+	}
+	polls := []*VoterPoll{}
+	for _, height := range heights {
+		mintTime, err := nc.archive.MintTime(height)
+		if err != nil {
+			return nil, err
+		}
+		buckets, err := nc.archive.Buckets(height)
+		if err != nil {
+			return nil, err
+		}
+		poll := &VoterPoll{Height: height, MintTime: mintTime}
+		for _, bucket := range buckets {
+			if !bytes.Equal(bucket.Voter(), voter) {
+				continue
+			}
+			vote, err := types.NewVote(bucket, types.CalcWeightedVotes(bucket, mintTime))
+			if err != nil {
+				return nil, err
+			}
+			poll.Votes = append(poll.Votes, vote)
+		}
+		if len(poll.Votes) != 0 {
+			polls = append(polls, poll)
+		}
+	}
+	return polls, nil
+}
+
+// SubscribeHeights returns a channel of the lowest height stored, and a function to cancel the subscription
+func (nc *NativeCommittee) SubscribeHeights() (<-chan uint64, func()) {
+	return nc.notifier.subscribe()
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+func TestNativeCommitteeResult(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	arch, err := NewBucketArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "native.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	defer func() {
+		require.NoError(arch.Stop(ctx))
+	}()
+	cache, err := lru.New(10)
+	require.NoError(err)
+	historyCache, err := lru.New(10)
+	require.NoError(err)
+	nc := &NativeCommittee{
+		archive:      arch,
+		cache:        cache,
+		historyCache: historyCache,
+		startHeight:  100,
+		interval:     10,
+		notifier:     newHeightNotifier(),
+	}
+
+	voter1 := bytes.Repeat([]byte{1}, 20)
+	voter2 := bytes.Repeat([]byte{2}, 20)
+	owner1, err := address.FromBytes(voter1)
+	require.NoError(err)
+	owner2, err := address.FromBytes(voter2)
+	require.NoError(err)
+	name := func(s string) (n [12]byte) {
+		copy(n[12-len(s):], s)
+		return
+	}
+	now := time.Unix(1600000000, 0)
+	require.NoError(arch.PutDelta(100, now, hash.ZeroHash256, []*pyggBucket{
+		{Index: 0, CanName: name("alice"), Amount: big.NewInt(100), StartTime: now, Owner: owner1},
+		{Index: 1, CanName: name("bob"), Amount: big.NewInt(300), StartTime: now, Owner: owner2},
+		{Index: 2, CanName: [12]byte{}, Amount: big.NewInt(50), StartTime: now, Owner: owner2},
+	}))
+	require.NoError(arch.PutDelta(110, now.Add(time.Hour), hash.ZeroHash256, []*pyggBucket{
+		{Index: 1, Amount: nil},
+		{Index: 3, CanName: name("alice"), Amount: big.NewInt(10), StartTime: now, Owner: owner2},
+	}))
+
+	aliceName, bobName := name("alice"), name("bob")
+	alice, bob := aliceName[:], bobName[:]
+	result, err := nc.ResultByHeight(100)
+	require.NoError(err)
+	require.True(now.Equal(result.MintTime()))
+	delegates := result.Delegates()
+	require.Equal(2, len(delegates))
+	require.Equal(bob, delegates[0].Name())
+	require.Equal(0, big.NewInt(300).Cmp(delegates[0].Score()))
+	require.Equal(alice, delegates[1].Name())
+	require.Equal(0, big.NewInt(0).Cmp(delegates[1].SelfStakingTokens()))
+
+	result, err = nc.ResultByHeight(110)
+	require.NoError(err)
+	delegates = result.Delegates()
+	require.Equal(1, len(delegates))
+	require.Equal(alice, delegates[0].Name())
+	require.Equal(0, big.NewInt(110).Cmp(delegates[0].Score()))
+	require.Equal(2, len(result.VotesByDelegate(alice)))
+
+	_, err = nc.ResultByHeight(105)
+	require.Error(err)
+	_, err = nc.ResultByHeight(120)
+	require.Error(err)
+
+	height, err := nc.HeightByTime(now.Add(30 * time.Minute))
+	require.NoError(err)
+	require.Equal(uint64(100), height)
+	height, err = nc.HeightByTime(now.Add(time.Hour))
+	require.NoError(err)
+	require.Equal(uint64(110), height)
+	// no height is settled after the tip
+	_, err = nc.HeightByTime(now.Add(2 * time.Hour))
+	require.Error(err)
+
+	points, err := nc.CandidateHistory(alice, 100, 110, 0)
+	require.NoError(err)
+	require.Equal(2, len(points))
+	require.Equal(2, points[0].Rank)
+	require.Equal(1, points[1].Rank)
+	require.Equal(2, points[1].VoterCount)
+
+	polls, err := nc.VoterHistory(voter2, 100, 110)
+	require.NoError(err)
+	require.Equal(2, len(polls))
+	require.Equal(2, len(polls[0].Votes))
+	require.Equal(2, len(polls[1].Votes))
+	require.Equal(uint64(110), polls[1].Height)
+}
@@ -13,6 +13,7 @@ package committee
 import (
 	"context"
 	"database/sql"
+	"io"
 	"os"
 	"reflect"
 	"sync"
@@ -25,11 +26,49 @@ import (
 	"github.com/pkg/errors"
 	_ "modernc.org/sqlite"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-election/db"
 	"github.com/iotexproject/iotex-election/types"
 	"github.com/iotexproject/iotex-election/util"
 )
 
+// ErrInconsistent indicates that the data in archive is inconsistent
+var ErrInconsistent = errors.New("inconsistent archive")
+
+type (
+	// Inconsistency describes the inconsistency of the poll of a height
+	Inconsistency struct {
+		Height  uint64
+		Reasons []string
+		// CorruptedRecords are the ids of the records not matching their hashes, indexed by table name
+		CorruptedRecords map[string][]int64
+	}
+
+	// RepairedPoll is the poll of an inconsistent height refetched from the chain
+	RepairedPoll struct {
+		Height        uint64
+		MintTime      time.Time
+		BlockHash     hash.Hash256
+		Registrations []*types.Registration
+		Buckets       []*types.Bucket
+	}
+
+	// CorruptedRecordsError is the error of records not matching their hashes
+	CorruptedRecordsError struct {
+		TableName string
+		IDs       []int64
+	}
+)
+
+func (e *CorruptedRecordsError) Error() string {
+	return errors.Wrapf(ErrInconsistent, "corrupted %s ids %s", e.TableName, atos(e.IDs)).Error()
+}
+
+// Cause returns ErrInconsistent
+func (e *CorruptedRecordsError) Cause() error {
+	return ErrInconsistent
+}
+
 // PollArchive stores registrations, buckets, and other data
 type PollArchive interface {
 	HeightBefore(time.Time) (uint64, error)
@@ -43,8 +82,24 @@ type PollArchive interface {
 	MintTime(uint64) (time.Time, error)
 	// NativeMintTime returns the mint time of a given epoch number
 	NativeMintTime(uint64) (time.Time, error)
+	// BlockHash returns the block hash of a given height
+	BlockHash(uint64) (hash.Hash256, error)
+	// VoterBuckets returns the buckets of a voter at the heights in a given range, skipping the heights without bucket
+	VoterBuckets([]byte, uint64, uint64) (map[uint64][]*types.Bucket, error)
 	// PutPoll puts one poll record
-	PutPoll(uint64, time.Time, []*types.Registration, []*types.Bucket) error
+	PutPoll(uint64, time.Time, hash.Hash256, []*types.Registration, []*types.Bucket) error
+	// Rollback deletes the poll records at and above a given height
+	Rollback(uint64) error
+	// Verify checks the consistency of the poll records from start height to tip height
+	Verify() ([]*Inconsistency, error)
+	// Repair deletes the corrupted records, replaces the inconsistent polls with the refetched ones in place, and rolls
+	// back to the lowest inconsistent height not refetched, which is returned, or 0 if nothing is rolled back
+	Repair([]*Inconsistency, []*RepairedPoll) (uint64, error)
+	// Export writes a snapshot of the polls from start height to tip height, and returns its manifest
+	Export(io.Writer) (*SnapshotManifest, error)
+	// Import verifies a snapshot against the trusted content hash, which is required unless it is insecure, and then
+	// reads it into an empty archive in one transaction
+	Import(io.ReadSeeker, string, bool) (*SnapshotManifest, error)
 	// PutNativePoll puts one native poll record on IoTeX chain
 	PutNativePoll(uint64, time.Time, []*types.Bucket) error
 	// TipHeight returns the tip height stored in archive
@@ -64,14 +119,64 @@ type archive struct {
 	registrationTableOperator Operator
 	timeTableOperator         *TimeTableOperator
 	nativeTimeTableOperator   *TimeTableOperator
+	blockHashTableOperator    *HashTableOperator
+	voterIndexOperator        *VoterIndexOperator
 	oldDB                     db.KVStoreWithNamespace
 	// Put (native) polls are synchronized to get rid of the risk of reading uncommitted changes from other tx on the
 	// same connection.
 	mutex sync.Mutex
 }
 
-// NewArchive creates a new archive of poll
-func NewArchive(dbPath string, numOfRetries uint8, startHeight uint64, interval uint64) (PollArchive, error) {
+// NewArchive creates a new archive of poll in the sql db defined in cfg
+func NewArchive(cfg db.Config, startHeight uint64, interval uint64) (PollArchive, error) {
+	driverName, err := ParseDriverType(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+	var kvstore db.KVStoreWithNamespace
+	if driverName == SQLITE {
+		if kvstore, err = oldCommitteeDB(cfg.DBPath, cfg.NumOfRetries); err != nil {
+			return nil, err
+		}
+	}
+	sqlDB, err := openDB(driverName, cfg)
+	if err != nil {
+		return nil, err
+	}
+	bucketTableOperator, err := NewBucketTableOperator("buckets", driverName)
+	if err != nil {
+		return nil, err
+	}
+	nativeBucketTableOperator, err := NewBucketTableOperator("native_buckets", driverName)
+	if err != nil {
+		return nil, err
+	}
+	registrationTableOperator, err := NewRegistrationTableOperator("registrations", driverName)
+	if err != nil {
+		return nil, err
+	}
+	voterIndexOperator, err := NewVoterIndexOperator("buckets", driverName)
+	if err != nil {
+		return nil, err
+	}
+	return &archive{
+		db:                        sqlDB,
+		startHeight:               startHeight,
+		interval:                  interval,
+		bucketTableOperator:       bucketTableOperator,
+		nativeBucketTableOperator: nativeBucketTableOperator,
+		registrationTableOperator: registrationTableOperator,
+		timeTableOperator:         NewTimeTableOperator("mint_time", driverName),
+		nativeTimeTableOperator:   NewTimeTableOperator("native_mint_time", driverName),
+		blockHashTableOperator:    NewHashTableOperator("block_hash", driverName),
+		voterIndexOperator:        voterIndexOperator,
+		oldDB:                     kvstore,
+	}, nil
+}
+
+// oldCommitteeDB returns the bolt db of the legacy committee to migrate from, if there is one. A legacy db at dbPath
+// is moved aside first to make room for the sqlite db.
+func oldCommitteeDB(dbPath string, numOfRetries uint8) (db.KVStoreWithNamespace, error) {
 	fileExists := func(path string) bool {
 		_, err := os.Stat(path)
 		if os.IsNotExist(err) {
@@ -108,33 +213,7 @@ func NewArchive(dbPath string, numOfRetries uint8, startHeight uint64, interval
 	if fileExists(oldDbPath) {
 		kvstore = db.NewBoltDB(oldDbPath, numOfRetries)
 	}
-	sqlDB, err := sql.Open("sqlite", dbPath)
-	if err != nil {
-		return nil, err
-	}
-	bucketTableOperator, err := NewBucketTableOperator("buckets", SQLITE)
-	if err != nil {
-		return nil, err
-	}
-	nativeBucketTableOperator, err := NewBucketTableOperator("native_buckets", SQLITE)
-	if err != nil {
-		return nil, err
-	}
-	registrationTableOperator, err := NewRegistrationTableOperator("registrations", SQLITE)
-	if err != nil {
-		return nil, err
-	}
-	return &archive{
-		db:                        sqlDB,
-		startHeight:               startHeight,
-		interval:                  interval,
-		bucketTableOperator:       bucketTableOperator,
-		nativeBucketTableOperator: nativeBucketTableOperator,
-		registrationTableOperator: registrationTableOperator,
-		timeTableOperator:         NewTimeTableOperator("mint_time", SQLITE),
-		nativeTimeTableOperator:   NewTimeTableOperator("native_mint_time", SQLITE),
-		oldDB:                     kvstore,
-	}, nil
+	return kvstore, nil
 }
 
 func (arch *archive) Registrations(height uint64) ([]*types.Registration, error) {
@@ -173,7 +252,7 @@ func (arch *archive) NativeBuckets(epochNum uint64) ([]*types.Bucket, error) {
 	return records, nil
 }
 
-func (arch *archive) PutPoll(height uint64, mintTime time.Time, regs []*types.Registration, buckets []*types.Bucket) (err error) {
+func (arch *archive) PutPoll(height uint64, mintTime time.Time, blockHash hash.Hash256, regs []*types.Registration, buckets []*types.Bucket) (err error) {
 	arch.mutex.Lock()
 	defer arch.mutex.Unlock()
 
@@ -182,18 +261,200 @@ func (arch *archive) PutPoll(height uint64, mintTime time.Time, regs []*types.Re
 		return err
 	}
 	defer tx.Rollback()
+	if err := arch.putPoll(height, mintTime, blockHash, regs, buckets, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (arch *archive) putPoll(
+	height uint64,
+	mintTime time.Time,
+	blockHash hash.Hash256,
+	regs []*types.Registration,
+	buckets []*types.Bucket,
+	tx *sql.Tx,
+) error {
 	if err := arch.registrationTableOperator.Put(height, regs, tx); err != nil {
 		return err
 	}
 	if err := arch.bucketTableOperator.Put(height, buckets, tx); err != nil {
 		return err
 	}
+	if err := arch.voterIndexOperator.Put(height, buckets, tx); err != nil {
+		return err
+	}
 	if err := arch.timeTableOperator.Put(height, mintTime, tx); err != nil {
 		return err
 	}
+	if blockHash != hash.ZeroHash256 {
+		if err := arch.blockHashTableOperator.Put(height, blockHash, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (arch *archive) Rollback(height uint64) (err error) {
+	arch.mutex.Lock()
+	defer arch.mutex.Unlock()
+
+	tx, err := arch.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := arch.registrationTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.bucketTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.voterIndexOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.timeTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
+	if err := arch.blockHashTableOperator.Rollback(height, tx); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
+func (arch *archive) Verify() ([]*Inconsistency, error) {
+	tipHeight, err := arch.TipHeight()
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+	inconsistencies := []*Inconsistency{}
+	lastPrintTime := time.Time{}
+	for height := arch.startHeight; height <= tipHeight; height += arch.interval {
+		if time.Since(lastPrintTime) > 5*time.Second {
+			zap.L().Info("verifying", zap.Uint64("height", height), zap.Uint64("tip", tipHeight))
+			lastPrintTime = time.Now()
+		}
+		inconsistency := &Inconsistency{Height: height}
+		for _, operator := range []Operator{
+			arch.timeTableOperator,
+			arch.blockHashTableOperator,
+			arch.registrationTableOperator,
+			arch.bucketTableOperator,
+		} {
+			err := operator.Verify(height, arch.db)
+			if err == nil {
+				continue
+			}
+			if errors.Cause(err) != ErrInconsistent {
+				return nil, err
+			}
+			inconsistency.Reasons = append(inconsistency.Reasons, err.Error())
+			if cre, ok := err.(*CorruptedRecordsError); ok {
+				if inconsistency.CorruptedRecords == nil {
+					inconsistency.CorruptedRecords = map[string][]int64{}
+				}
+				inconsistency.CorruptedRecords[cre.TableName] = cre.IDs
+			}
+		}
+		if len(inconsistency.Reasons) != 0 {
+			inconsistencies = append(inconsistencies, inconsistency)
+		}
+	}
+	return inconsistencies, nil
+}
+
+func (arch *archive) Repair(inconsistencies []*Inconsistency, polls []*RepairedPoll) (uint64, error) {
+	if len(inconsistencies) == 0 {
+		return 0, nil
+	}
+	height, err := arch.repair(inconsistencies, polls)
+	if err != nil {
+		return 0, err
+	}
+	// the voter index is derived from the buckets, and is rebuilt above the lowest height changed
+	return height, arch.indexVoters()
+}
+
+func (arch *archive) repair(inconsistencies []*Inconsistency, polls []*RepairedPoll) (uint64, error) {
+	arch.mutex.Lock()
+	defer arch.mutex.Unlock()
+
+	replaced := map[uint64]*RepairedPoll{}
+	for _, poll := range polls {
+		replaced[poll.Height] = poll
+	}
+	var rollbackHeight, indexHeight uint64
+	corrupted := map[string][]int64{}
+	for _, inconsistency := range inconsistencies {
+		if _, ok := replaced[inconsistency.Height]; !ok && (rollbackHeight == 0 || inconsistency.Height < rollbackHeight) {
+			rollbackHeight = inconsistency.Height
+		}
+		if indexHeight == 0 || inconsistency.Height < indexHeight {
+			indexHeight = inconsistency.Height
+		}
+		for tableName, ids := range inconsistency.CorruptedRecords {
+			corrupted[tableName] = append(corrupted[tableName], ids...)
+		}
+	}
+	tx, err := arch.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	ops := []*recordTableOperator{}
+	for _, operator := range []Operator{arch.registrationTableOperator, arch.bucketTableOperator} {
+		op, ok := operator.(*recordTableOperator)
+		if !ok {
+			return 0, errors.Errorf("unexpected type %s", reflect.TypeOf(operator))
+		}
+		// the corrupted records are only referred by inconsistent heights, which are replaced or rolled back
+		if err := op.deleteRecords(corrupted[op.tableName], tx); err != nil {
+			return 0, err
+		}
+		ops = append(ops, op)
+	}
+	for _, poll := range polls {
+		if rollbackHeight != 0 && poll.Height >= rollbackHeight {
+			continue
+		}
+		if err := ops[0].replace(poll.Height, poll.Registrations, tx); err != nil {
+			return 0, errors.Wrapf(err, "failed to replace registrations of height %d", poll.Height)
+		}
+		if err := ops[1].replace(poll.Height, poll.Buckets, tx); err != nil {
+			return 0, errors.Wrapf(err, "failed to replace buckets of height %d", poll.Height)
+		}
+		if err := arch.timeTableOperator.replace(poll.Height, poll.MintTime, tx); err != nil {
+			return 0, err
+		}
+		if poll.BlockHash != hash.ZeroHash256 {
+			if err := arch.blockHashTableOperator.Put(poll.Height, poll.BlockHash, tx); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if rollbackHeight != 0 {
+		for _, op := range ops {
+			if err := op.Rollback(rollbackHeight, tx); err != nil {
+				return 0, err
+			}
+		}
+		if err := arch.timeTableOperator.Rollback(rollbackHeight, tx); err != nil {
+			return 0, err
+		}
+		if err := arch.blockHashTableOperator.Rollback(rollbackHeight, tx); err != nil {
+			return 0, err
+		}
+	}
+	if err := arch.voterIndexOperator.Rollback(indexHeight, tx); err != nil {
+		return 0, err
+	}
+	return rollbackHeight, tx.Commit()
+}
+
 func (arch *archive) PutNativePoll(epochNum uint64, mintTime time.Time, buckets []*types.Bucket) (err error) {
 	arch.mutex.Lock()
 	defer arch.mutex.Unlock()
@@ -220,6 +481,22 @@ func (arch *archive) HeightBefore(ts time.Time) (uint64, error) {
 	return arch.timeTableOperator.HeightBefore(ts, arch.db, nil)
 }
 
+func (arch *archive) BlockHash(height uint64) (hash.Hash256, error) {
+	value, err := arch.blockHashTableOperator.Get(height, arch.db, nil)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	h, ok := value.(hash.Hash256)
+	if !ok {
+		return hash.ZeroHash256, errors.Errorf("Unexpected type %s", reflect.TypeOf(value))
+	}
+	return h, nil
+}
+
+func (arch *archive) VoterBuckets(voter []byte, fromHeight uint64, toHeight uint64) (map[uint64][]*types.Bucket, error) {
+	return arch.voterIndexOperator.Get(voter, fromHeight, toHeight, arch.db)
+}
+
 func (arch *archive) MintTime(height uint64) (time.Time, error) {
 	value, err := arch.timeTableOperator.Get(height, arch.db, nil)
 	if err != nil {
@@ -266,10 +543,70 @@ func (arch *archive) Start(ctx context.Context) (err error) {
 	if err = arch.nativeTimeTableOperator.CreateTables(tx); err != nil {
 		return err
 	}
+	if err = arch.blockHashTableOperator.CreateTables(tx); err != nil {
+		return err
+	}
+	if err = arch.voterIndexOperator.CreateTables(tx); err != nil {
+		return err
+	}
 	if err = tx.Commit(); err != nil {
 		return err
 	}
-	return arch.migrate(ctx)
+	if err = arch.migrate(ctx); err != nil {
+		return err
+	}
+	return arch.indexVoters()
+}
+
+// indexVoters builds the voter index of the polls archived before the index is introduced
+func (arch *archive) indexVoters() error {
+	tipHeight, err := arch.TipHeight()
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		return nil
+	default:
+		return err
+	}
+	height := arch.startHeight
+	indexedHeight, err := arch.voterIndexOperator.TipHeight(arch.db, nil)
+	switch errors.Cause(err) {
+	case nil:
+		height = indexedHeight + arch.interval
+	case db.ErrNotExist:
+	default:
+		return err
+	}
+	lastPrintTime := time.Time{}
+	for ; height <= tipHeight; height += arch.interval {
+		if time.Since(lastPrintTime) > 5*time.Second {
+			zap.L().Info("indexing voters", zap.Uint64("height", height), zap.Uint64("tip", tipHeight))
+			lastPrintTime = time.Now()
+		}
+		buckets, err := arch.Buckets(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read buckets of height %d", height)
+		}
+		if err := arch.putVoterIndex(height, buckets); err != nil {
+			return errors.Wrapf(err, "failed to index voters of height %d", height)
+		}
+	}
+	return nil
+}
+
+func (arch *archive) putVoterIndex(height uint64, buckets []*types.Bucket) error {
+	arch.mutex.Lock()
+	defer arch.mutex.Unlock()
+
+	tx, err := arch.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := arch.voterIndexOperator.Put(height, buckets, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (arch *archive) Stop(_ context.Context) (err error) {
@@ -288,7 +625,7 @@ func (arch *archive) migrateResult(height uint64, r *types.ElectionResult) error
 		buckets = append(buckets, &vote.Bucket)
 	}
 
-	return arch.PutPoll(height, r.MintTime(), regs, buckets)
+	return arch.PutPoll(height, r.MintTime(), hash.ZeroHash256, regs, buckets)
 }
 
 func (arch *archive) migrate(ctx context.Context) (err error) {
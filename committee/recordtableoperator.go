@@ -41,6 +41,10 @@ type Operator interface {
 	Put(uint64, interface{}, *sql.Tx) error
 	// TipHeight returns the tip height
 	TipHeight(*sql.DB, *sql.Tx) (uint64, error)
+	// Rollback deletes the values at and above height
+	Rollback(uint64, *sql.Tx) error
+	// Verify checks whether the value of height is consistent, and returns an error wrapping ErrInconsistent if not
+	Verify(uint64, *sql.DB) error
 }
 
 // Record defines a record
@@ -62,6 +66,8 @@ const (
 	SQLITE DRIVERTYPE = iota
 	//MYSQL stands for a mysql driver
 	MYSQL
+	//POSTGRES stands for a postgres driver
+	POSTGRES
 )
 
 type recordTableOperator struct {
@@ -75,6 +81,9 @@ type recordTableOperator struct {
 	lastHeightQuery            string
 	insertHeightToRecordsQuery string
 	insertIdenticalQuery       string
+	deleteRecordsQuery         string
+	rollbackQueries            []string
+	deleteHeightQueries        []string
 	tableCreations             []string
 
 	insertRecordsFunc InsertRecordsFunc
@@ -89,6 +98,8 @@ func NewBucketTableOperator(tableName string, driverName DRIVERTYPE) (Operator,
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTOINCREMENT, hash TEXT UNIQUE, start_time TIMESTAMP, duration TEXT, amount BLOB, decay INTEGER, voter BLOB, candidate BLOB)"
 	case MYSQL:
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTO_INCREMENT, hash VARCHAR(64) UNIQUE, start_time TIMESTAMP, duration TEXT, amount BLOB, decay INTEGER, voter BLOB, candidate BLOB)"
+	case POSTGRES:
+		creation = "CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, hash VARCHAR(64) UNIQUE, start_time TIMESTAMPTZ, duration TEXT, amount BYTEA, decay INTEGER, voter BYTEA, candidate BYTEA)"
 	default:
 		return nil, errors.New("Wrong driver type")
 	}
@@ -109,6 +120,8 @@ func NewRegistrationTableOperator(tableName string, driverName DRIVERTYPE) (Oper
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTOINCREMENT, hash TEXT UNIQUE, name BLOB, address BLOB, operator_address BLOB, reward_address BLOB, self_staking_weight INTEGER)"
 	case MYSQL:
 		creation = "CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTO_INCREMENT, hash VARCHAR(64) UNIQUE, name BLOB, address BLOB, operator_address BLOB, reward_address BLOB, self_staking_weight INTEGER)"
+	case POSTGRES:
+		creation = "CREATE TABLE IF NOT EXISTS %s (id BIGSERIAL PRIMARY KEY, hash VARCHAR(64) UNIQUE, name BYTEA, address BYTEA, operator_address BYTEA, reward_address BYTEA, self_staking_weight BIGINT)"
 	default:
 		return nil, errors.New("Wrong driver type")
 	}
@@ -129,33 +142,51 @@ func NewRecordTableOperator(
 	queryRecordsFunc QueryRecordsFunc,
 	recordTableCreation string,
 ) (Operator, error) {
-	var insertHeightToRecordsQuery, insertIdenticalQuery string
+	var insertHeightToRecordsQuery, insertIdenticalQuery, heightToRecordsCreation, identicalCreation string
 	switch driverName {
 	case SQLITE:
 		insertHeightToRecordsQuery = fmt.Sprintf("INSERT OR REPLACE INTO height_to_%s (height, ids, frequencies) VALUES (?, ?, ?)", tableName)
 		insertIdenticalQuery = fmt.Sprintf("INSERT OR IGNORE INTO identical_%s (height, identical_to) VALUES (?, ?)", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height INTEGER PRIMARY KEY, ids BLOB, frequencies BLOB)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height INTEGER PRIMARY KEY, identical_to INTEGER)"
 	case MYSQL:
 		insertHeightToRecordsQuery = fmt.Sprintf("REPLACE INTO height_to_%s (height, ids, frequencies) VALUES (?, ?, ?)", tableName)
 		insertIdenticalQuery = fmt.Sprintf("INSERT IGNORE INTO identical_%s (height, identical_to) VALUES (?, ?)", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height BIGINT PRIMARY KEY, ids LONGBLOB, frequencies LONGBLOB)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height BIGINT PRIMARY KEY, identical_to BIGINT)"
+	case POSTGRES:
+		insertHeightToRecordsQuery = fmt.Sprintf("INSERT INTO height_to_%s (height, ids, frequencies) VALUES (?, ?, ?) ON CONFLICT (height) DO UPDATE SET ids = EXCLUDED.ids, frequencies = EXCLUDED.frequencies", tableName)
+		insertIdenticalQuery = fmt.Sprintf("INSERT INTO identical_%s (height, identical_to) VALUES (?, ?) ON CONFLICT DO NOTHING", tableName)
+		heightToRecordsCreation = "CREATE TABLE IF NOT EXISTS height_to_%s (height BIGINT PRIMARY KEY, ids BYTEA, frequencies BYTEA)"
+		identicalCreation = "CREATE TABLE IF NOT EXISTS identical_%s (height BIGINT PRIMARY KEY, identical_to BIGINT)"
 	default:
 		return nil, errors.New("Wrong driver type")
 	}
 	return &recordTableOperator{
 		tableName:                  tableName,
 		driverName:                 driverName,
-		frequencyQuery:             fmt.Sprintf("SELECT ids, frequencies FROM height_to_%s WHERE height = ?", tableName),
+		frequencyQuery:             rebind(driverName, fmt.Sprintf("SELECT ids, frequencies FROM height_to_%s WHERE height = ?", tableName)),
 		hashQuery:                  fmt.Sprintf("SELECT id, hash FROM %s WHERE id IN (%s)", tableName, "%s"),
 		idQuery:                    fmt.Sprintf("SELECT id, hash FROM %s WHERE hash IN ('%s')", tableName, "%s"),
-		identicalQuery:             fmt.Sprintf("SELECT identical_to FROM identical_%s WHERE height = ?", tableName),
-		lastHeightQuery:            fmt.Sprintf("SELECT MAX(max_height) FROM (SELECT MAX(height) AS max_height FROM identical_%s WHERE height < ? UNION SELECT MAX(height) AS max_height FROM height_to_%s WHERE height < ?) AS height", tableName, tableName),
-		insertHeightToRecordsQuery: insertHeightToRecordsQuery,
-		insertIdenticalQuery:       insertIdenticalQuery,
+		identicalQuery:             rebind(driverName, fmt.Sprintf("SELECT identical_to FROM identical_%s WHERE height = ?", tableName)),
+		lastHeightQuery:            rebind(driverName, fmt.Sprintf("SELECT MAX(max_height) FROM (SELECT MAX(height) AS max_height FROM identical_%s WHERE height < ? UNION SELECT MAX(height) AS max_height FROM height_to_%s WHERE height < ?) AS height", tableName, tableName)),
+		insertHeightToRecordsQuery: rebind(driverName, insertHeightToRecordsQuery),
+		insertIdenticalQuery:       rebind(driverName, insertIdenticalQuery),
 		insertRecordsFunc:          insertRecordsFunc,
 		queryRecordsFunc:           queryRecordsFunc,
+		deleteRecordsQuery:         fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", tableName, "%s"),
+		rollbackQueries: []string{
+			rebind(driverName, fmt.Sprintf("DELETE FROM height_to_%s WHERE height >= ?", tableName)),
+			rebind(driverName, fmt.Sprintf("DELETE FROM identical_%s WHERE height >= ?", tableName)),
+		},
+		deleteHeightQueries: []string{
+			rebind(driverName, fmt.Sprintf("DELETE FROM height_to_%s WHERE height = ?", tableName)),
+			rebind(driverName, fmt.Sprintf("DELETE FROM identical_%s WHERE height = ?", tableName)),
+		},
 		tableCreations: []string{
 			fmt.Sprintf(recordTableCreation, tableName),
-			fmt.Sprintf("CREATE TABLE IF NOT EXISTS height_to_%s (height INTEGER PRIMARY KEY, ids BLOB, frequencies BLOB)", tableName),
-			fmt.Sprintf("CREATE TABLE IF NOT EXISTS identical_%s (height INTEGER PRIMARY KEY, identical_to INTEGER)", tableName),
+			fmt.Sprintf(heightToRecordsCreation, tableName),
+			fmt.Sprintf(identicalCreation, tableName),
 		},
 	}, nil
 }
@@ -177,20 +208,41 @@ func (arch *recordTableOperator) Get(height uint64, db *sql.DB, tx *sql.Tx) (int
 	return arch.queryRecordsFunc(arch.tableName, frequencies, db, tx)
 }
 
-func (arch *recordTableOperator) Put(height uint64, records interface{}, tx *sql.Tx) (err error) {
+func (arch *recordTableOperator) Put(height uint64, records interface{}, tx *sql.Tx) error {
+	return arch.put(height, records, true, tx)
+}
+
+// replace overwrites the records of height in place. The records are always stored rather than marked as identical
+// to the previous height, such that the heights identical to this one still resolve.
+func (arch *recordTableOperator) replace(height uint64, records interface{}, tx *sql.Tx) error {
+	for _, query := range arch.deleteHeightQueries {
+		if _, err := tx.Exec(query, util.Uint64ToInt64(height)); err != nil {
+			return err
+		}
+	}
+	return arch.put(height, records, false, tx)
+}
+
+func (arch *recordTableOperator) put(height uint64, records interface{}, allowIdentical bool, tx *sql.Tx) (err error) {
 	var hash2Frequencies map[hash.Hash256]int
-	var lastHeight uint64
 	if hash2Frequencies, err = arch.insertRecordsFunc(arch.tableName, arch.driverName, records, tx); err != nil {
 		return err
 	}
-	if lastHeight, err = arch.lastHeight(height, nil, tx); err != nil {
-		return err
-	}
-	lastIdenticalHeight, lastFrequencies, err := arch.hashes(lastHeight, nil, tx)
-	if err != nil {
-		return errors.Wrap(err, "failed to get record hashes")
+	identical := false
+	var lastIdenticalHeight uint64
+	if allowIdentical {
+		var lastHeight uint64
+		if lastHeight, err = arch.lastHeight(height, nil, tx); err != nil {
+			return err
+		}
+		var lastFrequencies map[hash.Hash256]int
+		lastIdenticalHeight, lastFrequencies, err = arch.hashes(lastHeight, nil, tx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get record hashes")
+		}
+		identical = arch.hasIdenticalRecords(hash2Frequencies, lastFrequencies)
 	}
-	if arch.hasIdenticalRecords(hash2Frequencies, lastFrequencies) {
+	if identical {
 		if _, err := tx.Exec(arch.insertIdenticalQuery, height, lastIdenticalHeight); err != nil {
 			return err
 		}
@@ -245,6 +297,158 @@ func (arch *recordTableOperator) Put(height uint64, records interface{}, tx *sql
 	return nil
 }
 
+func (arch *recordTableOperator) Rollback(height uint64, tx *sql.Tx) error {
+	for _, query := range arch.rollbackQueries {
+		if _, err := tx.Exec(query, util.Uint64ToInt64(height)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify checks that the records of height are stored, all ids are resolvable, the frequencies are valid, and the
+// records match their hashes. The records of a height identical to another one are verified with the other height.
+func (arch *recordTableOperator) Verify(height uint64, sdb *sql.DB) error {
+	target, err := arch.identicalTo(height, sdb, nil)
+	if err != nil {
+		return err
+	}
+	ids, frequencies, err := arch.rawFrequencies(target, sdb, nil)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		if target != height {
+			return errors.Wrapf(ErrInconsistent, "%s of height %d is identical to missing height %d", arch.tableName, height, target)
+		}
+		return errors.Wrapf(ErrInconsistent, "no %s at height %d", arch.tableName, height)
+	default:
+		return errors.Wrapf(ErrInconsistent, "invalid %s at height %d: %v", arch.tableName, target, err)
+	}
+	if target != height {
+		return nil
+	}
+	expected := make(map[int64]int, len(ids))
+	for _, id := range ids {
+		if _, ok := expected[id]; ok {
+			return errors.Wrapf(ErrInconsistent, "duplicate %s id %d at height %d", arch.tableName, id, height)
+		}
+		expected[id] = 1
+	}
+	for id, f := range frequencies {
+		if _, ok := expected[id]; !ok || f <= 1 {
+			return errors.Wrapf(ErrInconsistent, "invalid frequency %d of %s id %d at height %d", f, arch.tableName, id, height)
+		}
+		expected[id] = f
+	}
+	id2hash, err := arch.recordHashes(ids, sdb)
+	if err != nil {
+		return err
+	}
+	dangling := []int64{}
+	for _, id := range ids {
+		if _, ok := id2hash[id]; !ok {
+			dangling = append(dangling, id)
+		}
+	}
+	if len(dangling) != 0 {
+		return errors.Wrapf(ErrInconsistent, "dangling %s ids %s at height %d", arch.tableName, atos(dangling), height)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	expectedHashes := make(map[hash.Hash256]int, len(ids))
+	for id, f := range expected {
+		expectedHashes[id2hash[id]] += f
+	}
+	records, err := arch.queryRecordsFunc(arch.tableName, expected, sdb, nil)
+	if err != nil {
+		return err
+	}
+	hashes, err := hashesOf(records)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(expectedHashes, hashes) {
+		return nil
+	}
+	// look for the records not matching their hashes
+	corrupted := []int64{}
+	for _, id := range ids {
+		records, err := arch.queryRecordsFunc(arch.tableName, map[int64]int{id: 1}, sdb, nil)
+		if err != nil {
+			return err
+		}
+		hashes, err := hashesOf(records)
+		if err != nil {
+			return err
+		}
+		if hashes[id2hash[id]] != 1 || len(hashes) != 1 {
+			corrupted = append(corrupted, id)
+		}
+	}
+	if len(corrupted) == 0 {
+		return errors.Wrapf(ErrInconsistent, "mismatched %s at height %d", arch.tableName, height)
+	}
+	return &CorruptedRecordsError{TableName: arch.tableName, IDs: corrupted}
+}
+
+// hashesOf returns the hashes of a list of records with their frequencies
+func hashesOf(records interface{}) (map[hash.Hash256]int, error) {
+	value := reflect.ValueOf(records)
+	if value.Kind() != reflect.Slice {
+		return nil, errors.Errorf("invalid record list type %s", reflect.TypeOf(records))
+	}
+	hashes := make(map[hash.Hash256]int, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		record, ok := value.Index(i).Interface().(Record)
+		if !ok {
+			return nil, errors.Errorf("invalid record type %s", value.Index(i).Type())
+		}
+		h, err := record.Hash()
+		if err != nil {
+			return nil, err
+		}
+		hashes[h]++
+	}
+	return hashes, nil
+}
+
+func (arch *recordTableOperator) recordHashes(ids []int64, sdb *sql.DB) (map[int64]hash.Hash256, error) {
+	id2hash := make(map[int64]hash.Hash256, len(ids))
+	if len(ids) == 0 {
+		return id2hash, nil
+	}
+	rows, err := sdb.Query(fmt.Sprintf(arch.hashQuery, atos(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var val string
+		if err := rows.Scan(&id, &val); err != nil {
+			return nil, err
+		}
+		h, err := hash.HexStringToHash256(val)
+		if err != nil {
+			return nil, err
+		}
+		id2hash[id] = h
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return id2hash, nil
+}
+
+func (arch *recordTableOperator) deleteRecords(ids []int64, tx *sql.Tx) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(fmt.Sprintf(arch.deleteRecordsQuery, atos(ids)))
+	return err
+}
+
 func (arch *recordTableOperator) CreateTables(tx *sql.Tx) (err error) {
 	for _, creation := range arch.tableCreations {
 		if _, err = tx.Exec(creation); err != nil {
@@ -300,7 +504,7 @@ func (arch *recordTableOperator) hashes(height uint64, sdb *sql.DB, tx *sql.Tx)
 	return height, hashes, nil
 }
 
-func (arch *recordTableOperator) frequencies(height uint64, sdb *sql.DB, tx *sql.Tx) (map[int64]int, error) {
+func (arch *recordTableOperator) rawFrequencies(height uint64, sdb *sql.DB, tx *sql.Tx) ([]int64, map[int64]int, error) {
 	var (
 		bidBytes, timeBytes []byte
 		ids                 []int64
@@ -314,15 +518,23 @@ func (arch *recordTableOperator) frequencies(height uint64, sdb *sql.DB, tx *sql
 	}
 	switch err {
 	case sql.ErrNoRows:
-		return nil, db.ErrNotExist
+		return nil, nil, db.ErrNotExist
 	case nil:
 	default:
-		return nil, err
+		return nil, nil, err
 	}
 	if err = json.Unmarshal(bidBytes, &ids); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err = json.Unmarshal(timeBytes, &frequencies); err != nil {
+		return nil, nil, err
+	}
+	return ids, frequencies, nil
+}
+
+func (arch *recordTableOperator) frequencies(height uint64, sdb *sql.DB, tx *sql.Tx) (map[int64]int, error) {
+	ids, frequencies, err := arch.rawFrequencies(height, sdb, tx)
+	if err != nil {
 		return nil, err
 	}
 	bid2Times := make(map[int64]int, len(ids))
@@ -461,6 +673,9 @@ const InsertBucketsQuerySQLITE = "INSERT OR IGNORE INTO %s (hash, start_time, du
 // InsertBucketsQueryMySQL is query to insert buckets in MYSQL driver
 const InsertBucketsQueryMySQL = "INSERT IGNORE INTO %s (hash, start_time, duration, amount, decay, voter, candidate) VALUES (?, ?, ?, ?, ?, ?, ?)"
 
+// InsertBucketsQueryPostgres is query to insert buckets in POSTGRES driver
+const InsertBucketsQueryPostgres = "INSERT INTO %s (hash, start_time, duration, amount, decay, voter, candidate) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING"
+
 // InsertBuckets inserts bucket records into table by tx
 func InsertBuckets(tableName string, driverName DRIVERTYPE, records interface{}, tx *sql.Tx) (frequencies map[hash.Hash256]int, err error) {
 	buckets, ok := records.([]*types.Bucket)
@@ -476,6 +691,8 @@ func InsertBuckets(tableName string, driverName DRIVERTYPE, records interface{},
 		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQuerySQLITE, tableName))
 	case MYSQL:
 		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQueryMySQL, tableName))
+	case POSTGRES:
+		stmt, err = tx.Prepare(fmt.Sprintf(InsertBucketsQueryPostgres, tableName))
 	default:
 		return nil, errors.New("wrong driver type")
 	}
@@ -504,7 +721,7 @@ func InsertBuckets(tableName string, driverName DRIVERTYPE, records interface{},
 			bucket.StartTime(),
 			bucket.Duration().String(),
 			bucket.Amount().Bytes(),
-			bucket.Decay(),
+			boolToInt64(bucket.Decay()),
 			bucket.Voter(),
 			bucket.Candidate(),
 		); err != nil {
@@ -565,6 +782,9 @@ const InsertRegistrationQuerySQLITE = "INSERT OR IGNORE INTO %s (hash, name, add
 // InsertRegistrationQueryMySQL is query to insert registrations in MySQL driver
 const InsertRegistrationQueryMySQL = "INSERT IGNORE INTO %s (hash, name, address, operator_address, reward_address, self_staking_weight) VALUES (?, ?, ?, ?, ?, ?)"
 
+// InsertRegistrationQueryPostgres is query to insert registrations in Postgres driver
+const InsertRegistrationQueryPostgres = "INSERT INTO %s (hash, name, address, operator_address, reward_address, self_staking_weight) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING"
+
 // InsertRegistrations inserts registration records into table by tx
 func InsertRegistrations(tableName string, driverName DRIVERTYPE, records interface{}, tx *sql.Tx) (frequencies map[hash.Hash256]int, err error) {
 	regs, ok := records.([]*types.Registration)
@@ -580,9 +800,14 @@ func InsertRegistrations(tableName string, driverName DRIVERTYPE, records interf
 		regStmt, err = tx.Prepare(fmt.Sprintf(InsertRegistrationQuerySQLITE, tableName))
 	case MYSQL:
 		regStmt, err = tx.Prepare(fmt.Sprintf(InsertRegistrationQueryMySQL, tableName))
+	case POSTGRES:
+		regStmt, err = tx.Prepare(fmt.Sprintf(InsertRegistrationQueryPostgres, tableName))
 	default:
 		return nil, errors.New("wrong driver type")
 	}
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		closeErr := regStmt.Close()
 		if err == nil && closeErr != nil {
@@ -0,0 +1,214 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/carrier"
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+func startReplayCommittee(t *testing.T) Committee {
+	require := require.New(t)
+	ctx := context.Background()
+	replayCarrier, err := carrier.NewReplayCarrier(filepath.Join("testdata", "replay.jsonl"))
+	require.NoError(err)
+	arch, err := NewArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	c, err := NewCommitteeWithCarrier(arch, replayCarrier, Config{
+		NumOfRetries:               3,
+		GravityChainHeightInterval: 10,
+		GravityChainStartHeight:    100,
+		PaginationSize:             2,
+		VoteThreshold:              "0",
+		ScoreThreshold:             "0",
+		SelfStakingThreshold:       "0",
+		CacheSize:                  10,
+	})
+	require.NoError(err)
+	require.NoError(c.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(c.Stop(ctx))
+	})
+	deadline := time.Now().Add(10 * time.Second)
+	for c.LatestHeight() < 140 {
+		require.True(time.Now().Before(deadline), "timeout")
+		time.Sleep(10 * time.Millisecond)
+	}
+	return c
+}
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+	c := startReplayCommittee(t)
+
+	expected := map[uint64][][2]string{
+		100: {{"delegate2", "39663112348767121351223"}, {"delegate1", "32771856207484561673838"}, {"delegate3", "29101839655020842956289"}},
+		110: {{"delegate3", "45298410256773869093649"}, {"delegate2", "39718364278508084821429"}, {"delegate1", "32816040841518274011613"}},
+		120: {{"delegate1", "50505060172438719003906"}, {"delegate3", "45354042364916524956442"}, {"delegate2", "39773616208249048291634"}},
+		130: {{"delegate1", "50505060172438719003906"}, {"delegate3", "45354042364916524956442"}, {"delegate2", "39773616208249048291634"}},
+		140: {{"delegate3", "64434651423430277668024"}, {"delegate2", "56024120067730975232046"}, {"delegate1", "50616769168941179040112"}},
+	}
+	for height, delegates := range expected {
+		result, err := c.ResultByHeight(height)
+		require.NoError(err)
+		require.Equal(int64(1550000000+height*15), result.MintTime().Unix())
+		require.Equal(len(delegates), len(result.Delegates()))
+		for i, delegate := range result.Delegates() {
+			require.Equal(delegates[i][0], string(bytes.TrimLeft(delegate.Name(), "\x00")))
+			require.Equal(delegates[i][1], delegate.Score().String())
+		}
+	}
+
+	voter := []byte{0x01, 0xaa}
+	polls, err := c.VoterHistory(voter, 0, 1000)
+	require.NoError(err)
+	require.Equal(len(expected), len(polls))
+	for _, poll := range polls {
+		result, err := c.ResultByHeight(poll.Height)
+		require.NoError(err)
+		require.True(result.MintTime().Equal(poll.MintTime))
+		votes := []*types.Vote{}
+		for _, vote := range result.Votes() {
+			if bytes.Equal(voter, vote.Voter()) {
+				votes = append(votes, vote)
+			}
+		}
+		require.Equal(len(votes), len(poll.Votes))
+		for i, vote := range poll.Votes {
+			require.True(votes[i].Equal(vote))
+		}
+	}
+}
+
+func TestResultByEpoch(t *testing.T) {
+	require := require.New(t)
+	c := startReplayCommittee(t)
+
+	// the epoch is minted between heights 120 and 130
+	mintTime := time.Unix(1550000000+125*15, 0)
+	name := append([]byte{0, 0, 0}, []byte("delegate2")...)
+	bucket, err := types.NewBucket(mintTime, 0, big.NewInt(1000), []byte("native voter"), name, false)
+	require.NoError(err)
+	require.NoError(c.PutNativePollByEpoch(7, mintTime, []*types.Bucket{bucket}))
+
+	result, err := c.ResultByEpoch(7)
+	require.NoError(err)
+	require.True(mintTime.Equal(result.MintTime()))
+	height, err := c.HeightByTime(result.MintTime())
+	require.NoError(err)
+	require.Equal(uint64(120), height)
+	gravityResult, err := c.ResultByHeight(120)
+	require.NoError(err)
+	require.Equal(len(gravityResult.Delegates()), len(result.Delegates()))
+	require.Equal(
+		new(big.Int).Add(gravityResult.TotalVotedStakes(), big.NewInt(1000)).String(),
+		result.TotalVotedStakes().String(),
+	)
+	require.Equal(len(gravityResult.VotesByDelegate(name))+1, len(result.VotesByDelegate(name)))
+	cached, err := c.ResultByEpoch(7)
+	require.NoError(err)
+	require.Equal(result, cached)
+
+	_, err = c.ResultByEpoch(8)
+	require.Error(err)
+}
+
+func TestFetchDataReorganized(t *testing.T) {
+	require := require.New(t)
+	fixtures := []string{
+		`{"method":"Registrations","height":130,"index":"1","count":2,"nextIndex":"2","records":["CgwAAABkZWxlZ2F0ZTESAgEBGgIBAiICAQMoZA=="]}`,
+		`{"method":"HasStakingEvents","from":"121","to":"130"}`,
+		`{"method":"BlockTimestamp","height":130,"timestamp":1550001950}`,
+		`{"method":"BlockHash","height":130,"hash":"0x40e5b3ba79114ba91ce72248452926d84d47a16e2d570e8d55e7e3c25e48b07d"}`,
+	}
+	path := filepath.Join(t.TempDir(), "replay.jsonl")
+	write := func(lines ...string) *committee {
+		require.NoError(os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+		replayCarrier, err := carrier.NewReplayCarrier(path)
+		require.NoError(err)
+		arch, err := NewArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}, 100, 10)
+		require.NoError(err)
+		c, err := NewCommitteeWithCarrier(arch, replayCarrier, Config{
+			NumOfRetries:               3,
+			GravityChainHeightInterval: 10,
+			GravityChainStartHeight:    100,
+			PaginationSize:             2,
+			VoteThreshold:              "0",
+			ScoreThreshold:             "0",
+			SelfStakingThreshold:       "0",
+			CacheSize:                  10,
+		})
+		require.NoError(err)
+		return c.(*committee)
+	}
+
+	data, err := write(fixtures...).fetchDataByHeight(130, false)
+	require.NoError(err)
+	require.Equal("40e5b3ba79114ba91ce72248452926d84d47a16e2d570e8d55e7e3c25e48b07d", hex.EncodeToString(data.blockHash[:]))
+	require.Equal(1, len(data.registrations))
+	require.True(data.noNewStakingEvent)
+
+	// the block is replaced after the registrations are read
+	_, err = write(append(
+		fixtures,
+		`{"method":"BlockHash","height":130,"hash":"0x7521d1cadbcfa91eec65aa16715b94ffc1c9654ba57ea2ef1a2127bca1127a83"}`,
+	)...).fetchDataByHeight(130, false)
+	require.Error(err)
+	require.Contains(err.Error(), "reorganized")
+}
+
+func TestRepairArchive(t *testing.T) {
+	require := require.New(t)
+	ec := startReplayCommittee(t).(*committee)
+	buckets, err := ec.archive.Buckets(140)
+	require.NoError(err)
+	sqlDB := ec.archive.(*archive).db
+	_, err = sqlDB.Exec("DELETE FROM mint_time WHERE height = 120")
+	require.NoError(err)
+	_, err = sqlDB.Exec("UPDATE height_to_buckets SET ids = '[999]' WHERE height = 140")
+	require.NoError(err)
+	inconsistencies, err := ec.archive.Verify()
+	require.NoError(err)
+	require.Equal(2, len(inconsistencies))
+
+	// the inconsistent polls are refetched and repaired in place
+	height, err := ec.repair(inconsistencies)
+	require.NoError(err)
+	require.Equal(uint64(0), height)
+	inconsistencies, err = ec.archive.Verify()
+	require.NoError(err)
+	require.Equal(0, len(inconsistencies))
+	tip, err := ec.archive.TipHeight()
+	require.NoError(err)
+	require.Equal(uint64(140), tip)
+	mintTime, err := ec.archive.MintTime(120)
+	require.NoError(err)
+	require.Equal(int64(1550000000+120*15), mintTime.Unix())
+	repaired, err := ec.archive.Buckets(140)
+	require.NoError(err)
+	require.Equal(len(buckets), len(repaired))
+	for i, bucket := range repaired {
+		require.True(buckets[i].Equal(bucket))
+	}
+}
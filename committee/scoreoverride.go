@@ -0,0 +1,166 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-election/types"
+)
+
+type (
+	// ScoreOverride defines the score, and optionally the self staking tokens, of a candidate to be enforced on the
+	// election result of a height
+	ScoreOverride struct {
+		Height uint64 `yaml:"height" json:"height"`
+		// CandidateName is the hex string of the candidate name
+		CandidateName     string `yaml:"candidateName" json:"candidateName"`
+		Score             string `yaml:"score" json:"score"`
+		SelfStakingTokens string `yaml:"selfStakingTokens,omitempty" json:"selfStakingTokens,omitempty"`
+	}
+
+	scoreOverride struct {
+		score             *big.Int
+		selfStakingTokens *big.Int
+	}
+
+	// scoreOverrides is indexed by height and hex candidate name
+	scoreOverrides map[uint64]map[string]*scoreOverride
+)
+
+// defaultScoreOverrides corrects the scores of candidates at the height of ethereum hard fork
+var defaultScoreOverrides = []ScoreOverride{
+	{Height: EthHardForkHeight, CandidateName: "000000696f746578636f7265", Score: "85373235544231218078559584"},
+	{Height: EthHardForkHeight, CandidateName: "00000000006d6574616e7978", Score: "59632560935643656968902530"},
+	{Height: EthHardForkHeight, CandidateName: "67616d6566616e7461737900", Score: "53200765151851838442704552"},
+	{Height: EthHardForkHeight, CandidateName: "00000000707265616e67656c", Score: "50419789330925706718338211"},
+	{Height: EthHardForkHeight, CandidateName: "00007976616c696461746f72", Score: "49956076291800440218188229"},
+	{Height: EthHardForkHeight, CandidateName: "000000636f696e6765636b6f", Score: "45541967921325925112783154"},
+	{Height: EthHardForkHeight, CandidateName: "000000696f7465787465616d", Score: "42048368188254741149181523"},
+	{Height: EthHardForkHeight, CandidateName: "0000626c6f636b666f6c696f", Score: "40118847473353343676639849"},
+	{Height: EthHardForkHeight, CandidateName: "696f7478706c6f726572696f", Score: "38637407472542613934244717"},
+	{Height: EthHardForkHeight, CandidateName: "0068756f626977616c6c6574", Score: "19826333897499304850764901"},
+	{Height: EthHardForkHeight, CandidateName: "636f6e73656e7375736e6574", Score: "4562820963931216603918007"},
+}
+
+// DefaultScoreOverrides returns the score overrides applied if no override file is configured
+func DefaultScoreOverrides() []ScoreOverride {
+	retval := make([]ScoreOverride, len(defaultScoreOverrides))
+	copy(retval, defaultScoreOverrides)
+	return retval
+}
+
+// LoadScoreOverrides loads score overrides from a json file, or a yaml file otherwise
+func LoadScoreOverrides(path string) ([]ScoreOverride, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read score override file %s", path)
+	}
+	var overrides []ScoreOverride
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &overrides)
+	} else {
+		err = yaml.Unmarshal(data, &overrides)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse score override file %s", path)
+	}
+	return overrides, nil
+}
+
+func newScoreOverrides(overrides []ScoreOverride) (scoreOverrides, error) {
+	retval := scoreOverrides{}
+	for _, o := range overrides {
+		name, err := hex.DecodeString(o.CandidateName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid candidate name %s in score override", o.CandidateName)
+		}
+		key := hex.EncodeToString(name)
+		score, ok := new(big.Int).SetString(o.Score, 10)
+		if !ok || score.Sign() < 0 {
+			return nil, errors.Errorf("invalid score %s of candidate %s in score override", o.Score, key)
+		}
+		override := &scoreOverride{score: score}
+		if o.SelfStakingTokens != "" {
+			override.selfStakingTokens, ok = new(big.Int).SetString(o.SelfStakingTokens, 10)
+			if !ok || override.selfStakingTokens.Sign() < 0 {
+				return nil, errors.Errorf(
+					"invalid self staking tokens %s of candidate %s in score override",
+					o.SelfStakingTokens,
+					key,
+				)
+			}
+		}
+		if _, ok := retval[o.Height]; !ok {
+			retval[o.Height] = map[string]*scoreOverride{}
+		}
+		if _, ok := retval[o.Height][key]; ok {
+			return nil, errors.Errorf("duplicate score override of candidate %s at height %d", key, o.Height)
+		}
+		retval[o.Height][key] = override
+	}
+	return retval, nil
+}
+
+// apply overrides the scores of the delegates in result of height
+func (so scoreOverrides) apply(height uint64, result *types.ElectionResult) {
+	overrides, ok := so[height]
+	if !ok {
+		return
+	}
+	for _, delegate := range result.Delegates() {
+		name := hex.EncodeToString(delegate.Name())
+		override, ok := overrides[name]
+		if !ok {
+			continue
+		}
+		zap.L().Debug("override score", zap.Uint64("height", height), zap.String("name", name))
+		delegate.SetScore(new(big.Int).Set(override.score))
+		if override.selfStakingTokens != nil {
+			delegate.SetSelfStakingTokens(new(big.Int).Set(override.selfStakingTokens))
+		}
+	}
+}
+
+// list returns the overrides sorted by height and candidate name
+func (so scoreOverrides) list() []ScoreOverride {
+	retval := []ScoreOverride{}
+	for height, overrides := range so {
+		for name, override := range overrides {
+			o := ScoreOverride{
+				Height:        height,
+				CandidateName: name,
+				Score:         override.score.String(),
+			}
+			if override.selfStakingTokens != nil {
+				o.SelfStakingTokens = override.selfStakingTokens.String()
+			}
+			retval = append(retval, o)
+		}
+	}
+	sort.Slice(retval, func(i, j int) bool {
+		if retval[i].Height != retval[j].Height {
+			return retval[i].Height < retval[j].Height
+		}
+		return retval[i].CandidateName < retval[j].CandidateName
+	})
+	return retval
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/carrier"
+	"github.com/iotexproject/iotex-election/db"
+)
+
+func TestLoadScoreOverrides(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	expected := []ScoreOverride{
+		{Height: 10, CandidateName: "0000000000000064656c6567", Score: "100", SelfStakingTokens: "5"},
+		{Height: 20, CandidateName: "00000000000000000000abcd", Score: "0"},
+	}
+	yamlPath := filepath.Join(dir, "overrides.yaml")
+	require.NoError(ioutil.WriteFile(yamlPath, []byte(`
+- height: 10
+  candidateName: "0000000000000064656c6567"
+  score: "100"
+  selfStakingTokens: "5"
+- height: 20
+  candidateName: "00000000000000000000abcd"
+  score: "0"
+`), 0644))
+	overrides, err := LoadScoreOverrides(yamlPath)
+	require.NoError(err)
+	require.Equal(expected, overrides)
+
+	jsonPath := filepath.Join(dir, "overrides.json")
+	require.NoError(ioutil.WriteFile(jsonPath, []byte(`[
+		{"height": 10, "candidateName": "0000000000000064656c6567", "score": "100", "selfStakingTokens": "5"},
+		{"height": 20, "candidateName": "00000000000000000000abcd", "score": "0"}
+	]`), 0644))
+	overrides, err = LoadScoreOverrides(jsonPath)
+	require.NoError(err)
+	require.Equal(expected, overrides)
+
+	_, err = LoadScoreOverrides(filepath.Join(dir, "nonexist.yaml"))
+	require.Error(err)
+
+	so, err := newScoreOverrides(overrides)
+	require.NoError(err)
+	require.Equal(expected, so.list())
+
+	so, err = newScoreOverrides(DefaultScoreOverrides())
+	require.NoError(err)
+	require.Equal(11, len(so[EthHardForkHeight]))
+
+	for _, invalid := range [][]ScoreOverride{
+		{{Height: 1, CandidateName: "xyz", Score: "1"}},
+		{{Height: 1, CandidateName: "ab", Score: "-1"}},
+		{{Height: 1, CandidateName: "ab", Score: "1", SelfStakingTokens: "abc"}},
+		{{Height: 1, CandidateName: "ab", Score: "1"}, {Height: 1, CandidateName: "AB", Score: "2"}},
+	} {
+		_, err = newScoreOverrides(invalid)
+		require.Error(err)
+	}
+}
+
+func TestApplyScoreOverrides(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	name := hex.EncodeToString(append(make([]byte, 3), []byte("delegate1")...))
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	require.NoError(ioutil.WriteFile(path, []byte(`
+- height: 110
+  candidateName: "`+name+`"
+  score: "12345"
+  selfStakingTokens: "678"
+`), 0644))
+	replayCarrier, err := carrier.NewReplayCarrier(filepath.Join("testdata", "replay.jsonl"))
+	require.NoError(err)
+	arch, err := NewArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}, 100, 10)
+	require.NoError(err)
+	c, err := NewCommitteeWithCarrier(arch, replayCarrier, Config{
+		NumOfRetries:               3,
+		GravityChainHeightInterval: 10,
+		GravityChainStartHeight:    100,
+		PaginationSize:             2,
+		VoteThreshold:              "0",
+		ScoreThreshold:             "0",
+		SelfStakingThreshold:       "0",
+		CacheSize:                  10,
+		ScoreOverridesPath:         path,
+	})
+	require.NoError(err)
+	require.Equal(1, len(c.ScoreOverrides()))
+	require.NoError(c.Start(ctx))
+	defer func() {
+		require.NoError(c.Stop(ctx))
+	}()
+	deadline := time.Now().Add(10 * time.Second)
+	for c.LatestHeight() < 120 {
+		require.True(time.Now().Before(deadline), "timeout")
+		time.Sleep(10 * time.Millisecond)
+	}
+	for height, expected := range map[uint64]string{110: "12345", 120: "50505060172438719003906"} {
+		result, err := c.ResultByHeight(height)
+		require.NoError(err)
+		found := false
+		for _, delegate := range result.Delegates() {
+			if string(bytes.TrimLeft(delegate.Name(), "\x00")) != "delegate1" {
+				continue
+			}
+			found = true
+			require.Equal(expected, delegate.Score().String())
+			if height == 110 {
+				require.Equal("678", delegate.SelfStakingTokens().String())
+			}
+		}
+		require.True(found)
+	}
+}
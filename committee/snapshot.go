@@ -0,0 +1,415 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-election/db"
+	pb "github.com/iotexproject/iotex-election/pb/election"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+// SnapshotVersion is the version of the snapshot format
+const SnapshotVersion = 1
+
+// maxSnapshotFrameSize is the max size of a frame in snapshot
+const maxSnapshotFrameSize = 1 << 30
+
+// snapshotMagic is the leading bytes of a snapshot
+var snapshotMagic = []byte("IOTXPOLL")
+
+// SnapshotManifest describes the polls in a snapshot. A snapshot is a gzip stream of the magic bytes, the version,
+// and a list of length-prefixed frames, which are the polls from start height to last height, an empty frame, and the
+// manifest. The content hash is the sha256 hash of the frames of polls.
+type SnapshotManifest struct {
+	Version     uint32 `json:"version"`
+	StartHeight uint64 `json:"startHeight"`
+	Interval    uint64 `json:"interval"`
+	LastHeight  uint64 `json:"lastHeight"`
+	NumOfPolls  uint64 `json:"numOfPolls"`
+	ContentHash string `json:"contentHash"`
+}
+
+// ToProtoMsg converts the manifest to protobuf
+func (m *SnapshotManifest) ToProtoMsg() (*pb.SnapshotManifest, error) {
+	contentHash, err := hex.DecodeString(m.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SnapshotManifest{
+		Version:     m.Version,
+		StartHeight: m.StartHeight,
+		Interval:    m.Interval,
+		LastHeight:  m.LastHeight,
+		NumOfPolls:  m.NumOfPolls,
+		ContentHash: contentHash,
+	}, nil
+}
+
+// FromProtoMsg extracts the manifest from protobuf
+func (m *SnapshotManifest) FromProtoMsg(mPb *pb.SnapshotManifest) error {
+	m.Version = mPb.Version
+	m.StartHeight = mPb.StartHeight
+	m.Interval = mPb.Interval
+	m.LastHeight = mPb.LastHeight
+	m.NumOfPolls = mPb.NumOfPolls
+	m.ContentHash = hex.EncodeToString(mPb.ContentHash)
+	return nil
+}
+
+func writeSnapshotFrame(w io.Writer, data []byte) error {
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(data)))
+	if _, err := w.Write(prefix[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readSnapshotFrame(r *bufio.Reader, h io.Writer) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read frame size")
+	}
+	if size > maxSnapshotFrameSize {
+		return nil, errors.Errorf("frame size %d exceeds limit", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrap(err, "failed to read frame")
+	}
+	if h != nil && size > 0 {
+		var prefix [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(prefix[:], size)
+		h.Write(prefix[:n])
+		h.Write(data)
+	}
+	return data, nil
+}
+
+func (arch *archive) Export(w io.Writer) (*SnapshotManifest, error) {
+	tipHeight, err := arch.TipHeight()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tip height")
+	}
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(snapshotMagic); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(zw, binary.BigEndian, uint32(SnapshotVersion)); err != nil {
+		return nil, err
+	}
+	hasher := sha256.New()
+	fw := io.MultiWriter(zw, hasher)
+	manifest := &SnapshotManifest{
+		Version:     SnapshotVersion,
+		StartHeight: arch.startHeight,
+		Interval:    arch.interval,
+	}
+	var lastRegs, lastBuckets map[hash.Hash256]int
+	lastPrintTime := time.Time{}
+	for height := arch.startHeight; height <= tipHeight; height += arch.interval {
+		if time.Since(lastPrintTime) > 5*time.Second {
+			zap.L().Info("exporting", zap.Uint64("height", height), zap.Uint64("tip", tipHeight))
+			lastPrintTime = time.Now()
+		}
+		var pollPb *pb.SnapshotPoll
+		if pollPb, lastRegs, lastBuckets, err = arch.snapshotPoll(height, lastRegs, lastBuckets); err != nil {
+			return nil, errors.Wrapf(err, "failed to export poll of height %d", height)
+		}
+		data, err := proto.Marshal(pollPb)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeSnapshotFrame(fw, data); err != nil {
+			return nil, err
+		}
+		manifest.LastHeight = height
+		manifest.NumOfPolls++
+	}
+	if err := writeSnapshotFrame(zw, nil); err != nil {
+		return nil, err
+	}
+	manifest.ContentHash = hex.EncodeToString(hasher.Sum(nil))
+	mPb, err := manifest.ToProtoMsg()
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(mPb)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSnapshotFrame(zw, data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (arch *archive) snapshotPoll(
+	height uint64,
+	lastRegs map[hash.Hash256]int,
+	lastBuckets map[hash.Hash256]int,
+) (*pb.SnapshotPoll, map[hash.Hash256]int, map[hash.Hash256]int, error) {
+	mintTime, err := arch.MintTime(height)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ts, err := ptypes.TimestampProto(mintTime)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pollPb := &pb.SnapshotPoll{Height: height, MintTime: ts}
+	blockHash, err := arch.BlockHash(height)
+	switch errors.Cause(err) {
+	case nil:
+		pollPb.BlockHash = blockHash[:]
+	case db.ErrNotExist:
+	default:
+		return nil, nil, nil, err
+	}
+	regs, err := arch.Registrations(height)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	regHashes, err := hashesOf(regs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	buckets, err := arch.Buckets(height)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bucketHashes, err := hashesOf(buckets)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var regBytes, bucketBytes [][]byte
+	if lastRegs != nil && reflect.DeepEqual(lastRegs, regHashes) {
+		pollPb.IdenticalRegistrations = true
+	} else {
+		regBytes = make([][]byte, 0, len(regs))
+		for _, reg := range regs {
+			data, err := reg.Serialize()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			regBytes = append(regBytes, data)
+		}
+	}
+	if lastBuckets != nil && reflect.DeepEqual(lastBuckets, bucketHashes) {
+		pollPb.IdenticalBuckets = true
+	} else {
+		bucketBytes = make([][]byte, 0, len(buckets))
+		for _, bucket := range buckets {
+			data, err := bucket.Serialize()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			bucketBytes = append(bucketBytes, data)
+		}
+	}
+	if pollPb.Poll, err = types.NewPoll(bucketBytes, regBytes).ToProtoMsg(); err != nil {
+		return nil, nil, nil, err
+	}
+	return pollPb, regHashes, bucketHashes, nil
+}
+
+// pollWriter writes a poll read from snapshot
+type pollWriter func(uint64, time.Time, hash.Hash256, []*types.Registration, []*types.Bucket) error
+
+func (arch *archive) Import(r io.ReadSeeker, trustedHash string, insecure bool) (*SnapshotManifest, error) {
+	if trustedHash == "" && !insecure {
+		return nil, errors.New("trusted content hash of snapshot is required")
+	}
+	switch _, err := arch.TipHeight(); errors.Cause(err) {
+	case nil:
+		return nil, errors.New("failed to import snapshot into a non-empty archive")
+	case db.ErrNotExist:
+	default:
+		return nil, err
+	}
+	// the whole snapshot is verified before any poll is written
+	manifest, err := readSnapshot(r, arch.startHeight, arch.interval, trustedHash, func(uint64, time.Time, hash.Hash256, []*types.Registration, []*types.Bucket) error {
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	arch.mutex.Lock()
+	defer arch.mutex.Unlock()
+
+	tx, err := arch.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	// the snapshot is read again to be written in one transaction, which is committed only if it is the verified one
+	if _, err := readSnapshot(r, arch.startHeight, arch.interval, manifest.ContentHash, func(
+		height uint64,
+		mintTime time.Time,
+		blockHash hash.Hash256,
+		regs []*types.Registration,
+		buckets []*types.Bucket,
+	) error {
+		return arch.putPoll(height, mintTime, blockHash, regs, buckets, tx)
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// readSnapshot reads the polls in snapshot of start height and interval into writer, and verifies them against the
+// manifest and the trusted content hash if given
+func readSnapshot(r io.Reader, startHeight, interval uint64, trustedHash string, writer pollWriter) (*SnapshotManifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot")
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, errors.New("invalid snapshot")
+	}
+	var version uint32
+	if err := binary.Read(br, binary.BigEndian, &version); err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot")
+	}
+	if version != SnapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d", version)
+	}
+	hasher := sha256.New()
+	height := startHeight
+	var numOfPolls uint64
+	lastPrintTime := time.Time{}
+	for {
+		data, err := readSnapshotFrame(br, hasher)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			break
+		}
+		if time.Since(lastPrintTime) > 5*time.Second {
+			zap.L().Info("reading snapshot", zap.Uint64("height", height))
+			lastPrintTime = time.Now()
+		}
+		if err := readSnapshotPoll(height, data, numOfPolls == 0, writer); err != nil {
+			return nil, errors.Wrapf(err, "failed to import poll of height %d", height)
+		}
+		height += interval
+		numOfPolls++
+	}
+	data, err := readSnapshotFrame(br, nil)
+	if err != nil {
+		return nil, err
+	}
+	mPb := &pb.SnapshotManifest{}
+	if err := proto.Unmarshal(data, mPb); err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot manifest")
+	}
+	manifest := &SnapshotManifest{}
+	if err := manifest.FromProtoMsg(mPb); err != nil {
+		return nil, err
+	}
+	contentHash := hex.EncodeToString(hasher.Sum(nil))
+	switch {
+	case manifest.Version != version:
+		return nil, errors.Errorf("inconsistent snapshot version %d", manifest.Version)
+	case manifest.StartHeight != startHeight || manifest.Interval != interval:
+		return nil, errors.Errorf(
+			"snapshot of start height %d and interval %d does not match archive",
+			manifest.StartHeight,
+			manifest.Interval,
+		)
+	case manifest.NumOfPolls != numOfPolls || numOfPolls == 0 || manifest.LastHeight != height-interval:
+		return nil, errors.Errorf("snapshot of %d polls does not match manifest", numOfPolls)
+	case manifest.ContentHash != contentHash:
+		return nil, errors.Errorf("snapshot content hash %s does not match manifest", contentHash)
+	case trustedHash != "" && trustedHash != contentHash:
+		return nil, errors.Errorf("snapshot content hash %s is not trusted", contentHash)
+	}
+	return manifest, nil
+}
+
+func readSnapshotPoll(height uint64, data []byte, first bool, writer pollWriter) error {
+	pollPb := &pb.SnapshotPoll{}
+	if err := proto.Unmarshal(data, pollPb); err != nil {
+		return err
+	}
+	if pollPb.Height != height {
+		return errors.Errorf("unexpected height %d", pollPb.Height)
+	}
+	if first && (pollPb.IdenticalRegistrations || pollPb.IdenticalBuckets) {
+		return errors.New("no previous poll to be identical to")
+	}
+	mintTime, err := ptypes.Timestamp(pollPb.MintTime)
+	if err != nil {
+		return err
+	}
+	blockHash := hash.ZeroHash256
+	if len(pollPb.BlockHash) != 0 {
+		blockHash = hash.BytesToHash256(pollPb.BlockHash)
+	}
+	poll := &types.Poll{}
+	if err := poll.FromProtoMsg(pollPb.Poll); err != nil {
+		return err
+	}
+	// nil stands for identical to the previous poll
+	var regs []*types.Registration
+	if !pollPb.IdenticalRegistrations {
+		regs = make([]*types.Registration, 0, len(poll.Registrations()))
+		for _, data := range poll.Registrations() {
+			reg := &types.Registration{}
+			if err := reg.Deserialize(data); err != nil {
+				return err
+			}
+			regs = append(regs, reg)
+		}
+	}
+	var buckets []*types.Bucket
+	if !pollPb.IdenticalBuckets {
+		buckets = make([]*types.Bucket, 0, len(poll.Buckets()))
+		for _, data := range poll.Buckets() {
+			bucket := &types.Bucket{}
+			if err := bucket.Deserialize(data); err != nil {
+				return err
+			}
+			buckets = append(buckets, bucket)
+		}
+	}
+	return writer(height, mintTime, blockHash, regs, buckets)
+}
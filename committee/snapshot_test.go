@@ -0,0 +1,164 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	newArchive := func() PollArchive {
+		arch, err := NewArchive(db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}, 100, 10)
+		require.NoError(err)
+		require.NoError(arch.Start(ctx))
+		t.Cleanup(func() {
+			require.NoError(arch.Stop(ctx))
+		})
+		return arch
+	}
+	src := newArchive()
+	_, err := src.Export(&bytes.Buffer{})
+	require.Error(err)
+
+	now := time.Unix(1600000000, 0)
+	regs := []*types.Registration{
+		types.NewRegistration([]byte("candidate1"), []byte("addr1"), []byte("op1"), []byte("reward1"), 1),
+	}
+	for height := uint64(100); height <= 130; height += 10 {
+		var buckets []*types.Bucket
+		if height != 120 {
+			bucket, err := types.NewBucket(now, time.Hour, big.NewInt(int64(height)), []byte("voter"), []byte("candidate1"), false)
+			require.NoError(err)
+			buckets = []*types.Bucket{bucket, bucket}
+		}
+		require.NoError(src.PutPoll(height, now.Add(time.Duration(height)*time.Second), hash.Hash256b([]byte{byte(height)}), regs, buckets))
+	}
+	var snapshot bytes.Buffer
+	manifest, err := src.Export(&snapshot)
+	require.NoError(err)
+	require.Equal(uint32(SnapshotVersion), manifest.Version)
+	require.Equal(uint64(100), manifest.StartHeight)
+	require.Equal(uint64(10), manifest.Interval)
+	require.Equal(uint64(130), manifest.LastHeight)
+	require.Equal(uint64(4), manifest.NumOfPolls)
+
+	t.Run("import", func(t *testing.T) {
+		dst := newArchive()
+		imported, err := dst.Import(bytes.NewReader(snapshot.Bytes()), manifest.ContentHash, false)
+		require.NoError(err)
+		require.Equal(manifest, imported)
+		tip, err := dst.TipHeight()
+		require.NoError(err)
+		require.Equal(uint64(130), tip)
+		for height := uint64(100); height <= 130; height += 10 {
+			expectedMintTime, err := src.MintTime(height)
+			require.NoError(err)
+			mintTime, err := dst.MintTime(height)
+			require.NoError(err)
+			require.True(expectedMintTime.Equal(mintTime))
+			expectedHash, err := src.BlockHash(height)
+			require.NoError(err)
+			blockHash, err := dst.BlockHash(height)
+			require.NoError(err)
+			require.Equal(expectedHash, blockHash)
+			expectedRegs, err := src.Registrations(height)
+			require.NoError(err)
+			regs, err := dst.Registrations(height)
+			require.NoError(err)
+			require.Equal(expectedRegs, regs)
+			expectedBuckets, err := src.Buckets(height)
+			require.NoError(err)
+			buckets, err := dst.Buckets(height)
+			require.NoError(err)
+			require.Equal(len(expectedBuckets), len(buckets))
+			for i, bucket := range buckets {
+				require.True(expectedBuckets[i].Equal(bucket))
+			}
+		}
+		inconsistencies, err := dst.Verify()
+		require.NoError(err)
+		require.Equal(0, len(inconsistencies))
+		// an archive with polls cannot import
+		_, err = dst.Import(bytes.NewReader(snapshot.Bytes()), "", true)
+		require.Error(err)
+		// the same polls result in the same snapshot
+		var reexported bytes.Buffer
+		remanifest, err := dst.Export(&reexported)
+		require.NoError(err)
+		require.Equal(manifest.ContentHash, remanifest.ContentHash)
+	})
+	t.Run("untrusted", func(t *testing.T) {
+		dst := newArchive()
+		_, err := dst.Import(bytes.NewReader(snapshot.Bytes()), "00", false)
+		require.Error(err)
+		require.Contains(err.Error(), "not trusted")
+		// the trusted hash is required unless it is insecure
+		_, err = dst.Import(bytes.NewReader(snapshot.Bytes()), "", false)
+		require.Error(err)
+		require.Contains(err.Error(), "required")
+		_, err = dst.TipHeight()
+		require.Equal(db.ErrNotExist, err)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		zr, err := gzip.NewReader(bytes.NewReader(snapshot.Bytes()))
+		require.NoError(err)
+		raw, err := ioutil.ReadAll(zr)
+		require.NoError(err)
+		// change the last byte of the block hash of first poll
+		blockHash := hash.Hash256b([]byte{100})
+		i := bytes.Index(raw, blockHash[:])
+		require.True(i > 0)
+		raw[i+31]++
+		var tampered bytes.Buffer
+		zw := gzip.NewWriter(&tampered)
+		_, err = zw.Write(raw)
+		require.NoError(err)
+		require.NoError(zw.Close())
+		dst := newArchive()
+		_, err = dst.Import(bytes.NewReader(tampered.Bytes()), "", true)
+		require.Error(err)
+		require.Contains(err.Error(), "does not match manifest")
+		_, err = dst.TipHeight()
+		require.Equal(db.ErrNotExist, err)
+	})
+	t.Run("insecure", func(t *testing.T) {
+		dst := newArchive()
+		imported, err := dst.Import(bytes.NewReader(snapshot.Bytes()), "", true)
+		require.NoError(err)
+		require.Equal(manifest, imported)
+		tip, err := dst.TipHeight()
+		require.NoError(err)
+		require.Equal(uint64(130), tip)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		// the polls before the broken frame are not written
+		dst := newArchive()
+		_, err := dst.Import(bytes.NewReader(snapshot.Bytes()[:snapshot.Len()/2]), manifest.ContentHash, false)
+		require.Error(err)
+		_, err = dst.TipHeight()
+		require.Equal(db.ErrNotExist, err)
+	})
+}
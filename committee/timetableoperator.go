@@ -31,27 +31,38 @@ type TimeTableOperator struct {
 	insertMintTimeQuery string
 	mintTimeQuery       string
 	tipHeightQuery      string
+	rollbackQuery       string
+	deleteQuery         string
 	timeLayout          string
+	driverName          DRIVERTYPE
 }
 
 // NewTimeTableOperator returns an operator to time table
 func NewTimeTableOperator(tableName string, driverName DRIVERTYPE) *TimeTableOperator {
-	var insertMintTimeQuery string
+	var insertMintTimeQuery, createTableQuery string
 	switch driverName {
 	case SQLITE:
 		insertMintTimeQuery = fmt.Sprintf("INSERT OR IGNORE INTO %s (height, time) VALUES (?, ?)", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height INTEGER PRIMARY KEY, time TIMESTAMP)", tableName)
 	case MYSQL:
 		insertMintTimeQuery = fmt.Sprintf("INSERT IGNORE INTO %s (height, time) VALUES (?, ?)", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY, time DATETIME)", tableName)
+	case POSTGRES:
+		insertMintTimeQuery = fmt.Sprintf("INSERT INTO %s (height, time) VALUES (?, ?) ON CONFLICT DO NOTHING", tableName)
+		createTableQuery = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY, time TIMESTAMPTZ)", tableName)
 	default:
 		return nil
 	}
 	return &TimeTableOperator{
-		insertMintTimeQuery: insertMintTimeQuery,
-		createTableQuery:    fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height INTEGER PRIMARY KEY, time TIMESTAMP)", tableName),
-		heightQuery:         fmt.Sprintf("SELECT MAX(height) FROM %s WHERE ? >= time AND EXISTS (SELECT * FROM %s WHERE ? <= time)", tableName, tableName),
-		mintTimeQuery:       fmt.Sprintf("SELECT time FROM %s WHERE height = ?", tableName),
+		insertMintTimeQuery: rebind(driverName, insertMintTimeQuery),
+		createTableQuery:    createTableQuery,
+		heightQuery:         rebind(driverName, fmt.Sprintf("SELECT MAX(height) FROM %s WHERE ? >= time AND EXISTS (SELECT * FROM %s WHERE ? <= time)", tableName, tableName)),
+		mintTimeQuery:       rebind(driverName, fmt.Sprintf("SELECT time FROM %s WHERE height = ?", tableName)),
 		tipHeightQuery:      fmt.Sprintf("SELECT MAX(height) FROM %s", tableName),
+		rollbackQuery:       rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height >= ?", tableName)),
+		deleteQuery:         rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height = ?", tableName)),
 		timeLayout:          "2006-01-02 15:04:05-07:00",
+		driverName:          driverName,
 	}
 }
 
@@ -79,12 +90,11 @@ func (operator *TimeTableOperator) TipHeight(sdb *sql.DB, tx *sql.Tx) (uint64, e
 
 // HeightBefore returns the Height before ts in the time table
 func (operator *TimeTableOperator) HeightBefore(ts time.Time, sdb *sql.DB, tx *sql.Tx) (height uint64, err error) {
-	// convert to timestamp format as stored in DB
-	tsString := ts.UTC().Format(operator.timeLayout)
+	value := operator.timeValue(ts)
 	if tx != nil {
-		err = tx.QueryRow(operator.heightQuery, tsString, tsString).Scan(&height)
+		err = tx.QueryRow(operator.heightQuery, value, value).Scan(&height)
 	} else {
-		err = sdb.QueryRow(operator.heightQuery, tsString, tsString).Scan(&height)
+		err = sdb.QueryRow(operator.heightQuery, value, value).Scan(&height)
 	}
 	return uint64(height), err
 }
@@ -121,8 +131,38 @@ func (operator *TimeTableOperator) Put(height uint64, value interface{}, tx *sql
 	if !ok {
 		return errors.Errorf("unexpected type %s", reflect.TypeOf(value))
 	}
-	// convert to timestamp format as stored in DB
-	tsString := mintTime.UTC().Format(operator.timeLayout)
-	_, err := tx.Exec(operator.insertMintTimeQuery, height, tsString)
+	_, err := tx.Exec(operator.insertMintTimeQuery, util.Uint64ToInt64(height), operator.timeValue(mintTime))
+	return err
+}
+
+// Rollback deletes the mint times at and above height
+func (operator *TimeTableOperator) Rollback(height uint64, tx *sql.Tx) error {
+	_, err := tx.Exec(operator.rollbackQuery, util.Uint64ToInt64(height))
+	return err
+}
+
+// replace overwrites the mint time of height
+func (operator *TimeTableOperator) replace(height uint64, mintTime time.Time, tx *sql.Tx) error {
+	if _, err := tx.Exec(operator.deleteQuery, util.Uint64ToInt64(height)); err != nil {
+		return err
+	}
+	return operator.Put(height, mintTime, tx)
+}
+
+// timeValue converts ts to the value stored in DB
+func (operator *TimeTableOperator) timeValue(ts time.Time) interface{} {
+	if operator.driverName == SQLITE {
+		// sqlite compares timestamps as strings, so they are stored in a fixed format
+		return ts.UTC().Format(operator.timeLayout)
+	}
+	return ts.UTC()
+}
+
+// Verify checks whether the mint time of height exists
+func (operator *TimeTableOperator) Verify(height uint64, sdb *sql.DB) error {
+	_, err := operator.Get(height, sdb, nil)
+	if errors.Cause(err) == db.ErrNotExist {
+		return errors.Wrapf(ErrInconsistent, "no mint time at height %d", height)
+	}
 	return err
 }
@@ -0,0 +1,382 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-election/util"
+)
+
+// VoterIndexOperator defines an operator on the index of buckets by voter. A row of voter index is only written at
+// the height where the buckets of the voter change, with the ids of the bucket records of the voter since then. The
+// latest row of each voter is kept in a separate table, against which the buckets of a new height are compared.
+type VoterIndexOperator struct {
+	bucketTableName   string
+	tableCreations    []string
+	insertIndexQuery  string
+	insertLatestQuery string
+	insertHeightQuery string
+	idQuery           string
+	lastIndexesQuery  string
+	indexesQuery      string
+	heightsQuery      string
+	tipHeightQuery    string
+	rollbackQueries   []string
+	reconcileQueries  []string
+	queryRecordsFunc  QueryRecordsFunc
+}
+
+// NewVoterIndexOperator returns an operator to the voter index of a bucket table
+func NewVoterIndexOperator(bucketTableName string, driverName DRIVERTYPE) (*VoterIndexOperator, error) {
+	indexTable := "voter_index_" + bucketTableName
+	latestTable := "voter_latest_" + bucketTableName
+	heightTable := "voter_indexed_" + bucketTableName
+	var insertIndexQuery, insertLatestQuery, insertHeightQuery string
+	var tableCreations []string
+	switch driverName {
+	case SQLITE:
+		insertIndexQuery = fmt.Sprintf("INSERT OR REPLACE INTO %s (voter, height, ids) VALUES (?, ?, ?)", indexTable)
+		insertLatestQuery = fmt.Sprintf("INSERT OR REPLACE INTO %s (voter, height, ids) VALUES (?, ?, ?)", latestTable)
+		insertHeightQuery = fmt.Sprintf("INSERT OR IGNORE INTO %s (height) VALUES (?)", heightTable)
+		tableCreations = []string{
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter TEXT, height INTEGER, ids BLOB, PRIMARY KEY (voter, height))", indexTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter TEXT PRIMARY KEY, height INTEGER, ids BLOB)", latestTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height INTEGER PRIMARY KEY)", heightTable),
+		}
+	case MYSQL:
+		insertIndexQuery = fmt.Sprintf("REPLACE INTO %s (voter, height, ids) VALUES (?, ?, ?)", indexTable)
+		insertLatestQuery = fmt.Sprintf("REPLACE INTO %s (voter, height, ids) VALUES (?, ?, ?)", latestTable)
+		insertHeightQuery = fmt.Sprintf("INSERT IGNORE INTO %s (height) VALUES (?)", heightTable)
+		tableCreations = []string{
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter VARCHAR(64), height BIGINT, ids LONGBLOB, PRIMARY KEY (voter, height))", indexTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter VARCHAR(64) PRIMARY KEY, height BIGINT, ids LONGBLOB)", latestTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY)", heightTable),
+		}
+	case POSTGRES:
+		insertIndexQuery = fmt.Sprintf("INSERT INTO %s (voter, height, ids) VALUES (?, ?, ?) ON CONFLICT (voter, height) DO UPDATE SET ids = EXCLUDED.ids", indexTable)
+		insertLatestQuery = fmt.Sprintf("INSERT INTO %s (voter, height, ids) VALUES (?, ?, ?) ON CONFLICT (voter) DO UPDATE SET height = EXCLUDED.height, ids = EXCLUDED.ids", latestTable)
+		insertHeightQuery = fmt.Sprintf("INSERT INTO %s (height) VALUES (?) ON CONFLICT DO NOTHING", heightTable)
+		tableCreations = []string{
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter VARCHAR(64), height BIGINT, ids BYTEA, PRIMARY KEY (voter, height))", indexTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (voter VARCHAR(64) PRIMARY KEY, height BIGINT, ids BYTEA)", latestTable),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (height BIGINT PRIMARY KEY)", heightTable),
+		}
+	default:
+		return nil, errors.New("Wrong driver type")
+	}
+	return &VoterIndexOperator{
+		bucketTableName:   bucketTableName,
+		tableCreations:    tableCreations,
+		insertIndexQuery:  rebind(driverName, insertIndexQuery),
+		insertLatestQuery: rebind(driverName, insertLatestQuery),
+		insertHeightQuery: rebind(driverName, insertHeightQuery),
+		idQuery:           fmt.Sprintf("SELECT id, hash FROM %s WHERE hash IN ('%s')", bucketTableName, "%s"),
+		lastIndexesQuery:  fmt.Sprintf("SELECT voter, height, ids FROM %s", latestTable),
+		indexesQuery:      rebind(driverName, fmt.Sprintf("SELECT height, ids FROM %s WHERE voter = ? AND height <= ? ORDER BY height", indexTable)),
+		heightsQuery:      rebind(driverName, fmt.Sprintf("SELECT height FROM %s WHERE height >= ? AND height <= ? ORDER BY height", heightTable)),
+		tipHeightQuery:    fmt.Sprintf("SELECT MAX(height) FROM %s", heightTable),
+		rollbackQueries: []string{
+			rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height >= ?", indexTable)),
+			rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height >= ?", latestTable)),
+			rebind(driverName, fmt.Sprintf("DELETE FROM %s WHERE height >= ?", heightTable)),
+		},
+		// the latest rows not in the index are deleted, and the voters without latest row are filled from the index
+		reconcileQueries: []string{
+			fmt.Sprintf(
+				"DELETE FROM %s WHERE NOT EXISTS (SELECT 1 FROM %s AS v WHERE v.voter = %s.voter AND v.height = %s.height)",
+				latestTable,
+				indexTable,
+				latestTable,
+				latestTable,
+			),
+			fmt.Sprintf(
+				"INSERT INTO %s (voter, height, ids) SELECT voter, height, ids FROM %s AS v "+
+					"WHERE voter NOT IN (SELECT voter FROM %s) AND height = (SELECT MAX(height) FROM %s WHERE voter = v.voter)",
+				latestTable,
+				indexTable,
+				latestTable,
+				indexTable,
+			),
+		},
+		queryRecordsFunc: QueryBuckets,
+	}, nil
+}
+
+// CreateTables prepares the tables for the operator
+func (operator *VoterIndexOperator) CreateTables(tx *sql.Tx) error {
+	for _, creation := range operator.tableCreations {
+		if _, err := tx.Exec(creation); err != nil {
+			return err
+		}
+	}
+	return operator.reconcile(tx)
+}
+
+// reconcile rebuilds the latest rows of voters from the index
+func (operator *VoterIndexOperator) reconcile(tx *sql.Tx) error {
+	for _, query := range operator.reconcileQueries {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Put indexes the buckets of height by voter. The bucket records should have been stored in the same tx. Nil buckets
+// stand for identical to the previous height.
+func (operator *VoterIndexOperator) Put(height uint64, value interface{}, tx *sql.Tx) error {
+	buckets, ok := value.([]*types.Bucket)
+	if !ok {
+		return errors.Errorf("unexpected type %s", reflect.TypeOf(value))
+	}
+	if _, err := tx.Exec(operator.insertHeightQuery, util.Uint64ToInt64(height)); err != nil {
+		return err
+	}
+	if buckets == nil {
+		return nil
+	}
+	voterHashes := map[string][]string{}
+	for _, bucket := range buckets {
+		h, err := bucket.Hash()
+		if err != nil {
+			return err
+		}
+		voter := hex.EncodeToString(bucket.Voter())
+		voterHashes[voter] = append(voterHashes[voter], hex.EncodeToString(h[:]))
+	}
+	hash2ID, err := operator.ids(voterHashes, tx)
+	if err != nil {
+		return err
+	}
+	lastIndexes, err := operator.lastIndexes(height, tx)
+	if err != nil {
+		return err
+	}
+	indexes := make(map[string]string, len(voterHashes))
+	for voter, hashes := range voterHashes {
+		ids := make([]int64, 0, len(hashes))
+		for _, h := range hashes {
+			ids = append(ids, hash2ID[h])
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		idBytes, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		indexes[voter] = string(idBytes)
+	}
+	for voter, last := range lastIndexes {
+		if _, ok := indexes[voter]; !ok && last != "[]" {
+			indexes[voter] = "[]"
+		}
+	}
+	for voter, ids := range indexes {
+		if last, ok := lastIndexes[voter]; ok && last == ids {
+			continue
+		}
+		if _, err := tx.Exec(operator.insertIndexQuery, voter, util.Uint64ToInt64(height), []byte(ids)); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(operator.insertLatestQuery, voter, util.Uint64ToInt64(height), []byte(ids)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (operator *VoterIndexOperator) ids(voterHashes map[string][]string, tx *sql.Tx) (map[string]int64, error) {
+	hashes := []string{}
+	for _, hs := range voterHashes {
+		hashes = append(hashes, hs...)
+	}
+	hash2ID := make(map[string]int64, len(hashes))
+	if len(hashes) == 0 {
+		return hash2ID, nil
+	}
+	rows, err := tx.Query(fmt.Sprintf(operator.idQuery, strings.Join(hashes, "','")))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var h string
+		if err := rows.Scan(&id, &h); err != nil {
+			return nil, err
+		}
+		hash2ID[h] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, h := range hashes {
+		if _, ok := hash2ID[h]; !ok {
+			return nil, errors.Errorf("bucket record %s does not exist", h)
+		}
+	}
+	return hash2ID, nil
+}
+
+// lastIndexes returns the latest ids of voters, which should be below height as the heights are indexed in order
+func (operator *VoterIndexOperator) lastIndexes(height uint64, tx *sql.Tx) (map[string]string, error) {
+	rows, err := tx.Query(operator.lastIndexesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := map[string]string{}
+	for rows.Next() {
+		var voter string
+		var h int64
+		var ids []byte
+		if err := rows.Scan(&voter, &h, &ids); err != nil {
+			return nil, err
+		}
+		if uint64(h) >= height {
+			return nil, errors.Errorf("voter %s is indexed at height %d, not below %d", voter, h, height)
+		}
+		indexes[voter] = string(ids)
+	}
+	return indexes, rows.Err()
+}
+
+// Get returns the buckets of voter at the indexed heights in [fromHeight, toHeight]. The heights at which the voter
+// has no bucket are skipped.
+func (operator *VoterIndexOperator) Get(
+	voter []byte,
+	fromHeight uint64,
+	toHeight uint64,
+	sdb *sql.DB,
+) (map[uint64][]*types.Bucket, error) {
+	if fromHeight > toHeight {
+		return nil, errors.Errorf("invalid height range [%d, %d]", fromHeight, toHeight)
+	}
+	heights, err := operator.heights(fromHeight, toHeight, sdb)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sdb.Query(operator.indexesQuery, hex.EncodeToString(voter), util.Uint64ToInt64(toHeight))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	type index struct {
+		height uint64
+		ids    []int64
+	}
+	indexes := []*index{}
+	for rows.Next() {
+		var height int64
+		var idBytes []byte
+		if err := rows.Scan(&height, &idBytes); err != nil {
+			return nil, err
+		}
+		i := &index{height: uint64(height)}
+		if err := json.Unmarshal(idBytes, &i.ids); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	retval := map[uint64][]*types.Bucket{}
+	var current *index
+	var buckets []*types.Bucket
+	for _, height := range heights {
+		next := current
+		for len(indexes) > 0 && indexes[0].height <= height {
+			next = indexes[0]
+			indexes = indexes[1:]
+		}
+		if next == nil || len(next.ids) == 0 {
+			current = next
+			continue
+		}
+		if next != current {
+			frequencies := make(map[int64]int, len(next.ids))
+			for _, id := range next.ids {
+				frequencies[id]++
+			}
+			value, err := operator.queryRecordsFunc(operator.bucketTableName, frequencies, sdb, nil)
+			if err != nil {
+				return nil, err
+			}
+			var ok bool
+			if buckets, ok = value.([]*types.Bucket); !ok {
+				return nil, errors.Errorf("unexpected type %s", reflect.TypeOf(value))
+			}
+			current = next
+		}
+		retval[height] = buckets
+	}
+	return retval, nil
+}
+
+func (operator *VoterIndexOperator) heights(fromHeight uint64, toHeight uint64, sdb *sql.DB) ([]uint64, error) {
+	rows, err := sdb.Query(operator.heightsQuery, util.Uint64ToInt64(fromHeight), util.Uint64ToInt64(toHeight))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	heights := []uint64{}
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, err
+		}
+		heights = append(heights, uint64(height))
+	}
+	return heights, rows.Err()
+}
+
+// TipHeight returns the last indexed height
+func (operator *VoterIndexOperator) TipHeight(sdb *sql.DB, tx *sql.Tx) (uint64, error) {
+	var val sql.NullInt64
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(operator.tipHeightQuery).Scan(&val)
+	} else {
+		err = sdb.QueryRow(operator.tipHeightQuery).Scan(&val)
+	}
+	switch err {
+	case sql.ErrNoRows:
+		return 0, db.ErrNotExist
+	case nil:
+		if val.Valid {
+			return uint64(val.Int64), nil
+		}
+		return 0, db.ErrNotExist
+	default:
+		return 0, err
+	}
+}
+
+// Rollback deletes the index at and above height
+func (operator *VoterIndexOperator) Rollback(height uint64, tx *sql.Tx) error {
+	for _, query := range operator.rollbackQueries {
+		if _, err := tx.Exec(query, util.Uint64ToInt64(height)); err != nil {
+			return err
+		}
+	}
+	return operator.reconcile(tx)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package committee
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
+)
+
+func TestVoterIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := db.Config{DBPath: filepath.Join(t.TempDir(), "poll.db"), NumOfRetries: 3}
+	arch, err := NewArchive(cfg, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+
+	now := time.Unix(1600000000, 0)
+	newBucket := func(amount int64, voter string, candidate string) *types.Bucket {
+		bucket, err := types.NewBucket(now, time.Hour, big.NewInt(amount), []byte(voter), []byte(candidate), false)
+		require.NoError(err)
+		return bucket
+	}
+	b1 := newBucket(1, "voter1", "candidate1")
+	b2 := newBucket(2, "voter1", "candidate2")
+	b3 := newBucket(3, "voter2", "candidate1")
+	polls := map[uint64][]*types.Bucket{
+		100: {b1, b3},
+		110: {b1, b2, b3},
+		120: nil,
+		130: {b3},
+		140: {b2, b2},
+	}
+	for height := uint64(100); height <= 140; height += 10 {
+		require.NoError(arch.PutPoll(height, now, hash.ZeroHash256, []*types.Registration{}, polls[height]))
+	}
+	check := func(arch PollArchive) {
+		history, err := arch.VoterBuckets([]byte("voter1"), 0, 200)
+		require.NoError(err)
+		require.Equal(4, len(history))
+		require.Equal(1, len(history[100]))
+		require.True(b1.Equal(history[100][0]))
+		require.Equal(2, len(history[110]))
+		require.Equal(2, len(history[120]))
+		require.Equal(0, len(history[130]))
+		require.Equal(2, len(history[140]))
+		require.True(b2.Equal(history[140][0]))
+		require.True(b2.Equal(history[140][1]))
+		history, err = arch.VoterBuckets([]byte("voter2"), 115, 135)
+		require.NoError(err)
+		require.Equal(2, len(history))
+		require.True(b3.Equal(history[120][0]))
+		require.True(b3.Equal(history[130][0]))
+		history, err = arch.VoterBuckets([]byte("voter3"), 0, 200)
+		require.NoError(err)
+		require.Equal(0, len(history))
+		_, err = arch.VoterBuckets([]byte("voter1"), 200, 100)
+		require.Error(err)
+	}
+	check(arch)
+
+	require.NoError(arch.Rollback(130))
+	history, err := arch.VoterBuckets([]byte("voter1"), 0, 200)
+	require.NoError(err)
+	require.Equal(3, len(history))
+	require.NoError(arch.PutPoll(130, now, hash.ZeroHash256, []*types.Registration{}, polls[130]))
+	require.NoError(arch.PutPoll(140, now, hash.ZeroHash256, []*types.Registration{}, polls[140]))
+	check(arch)
+
+	// the index of an archive without index is built on start
+	_, err = arch.(*archive).db.Exec("DROP TABLE voter_index_buckets")
+	require.NoError(err)
+	_, err = arch.(*archive).db.Exec("DROP TABLE voter_indexed_buckets")
+	require.NoError(err)
+	require.NoError(arch.Stop(ctx))
+	arch, err = NewArchive(cfg, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	defer func() {
+		require.NoError(arch.Stop(ctx))
+	}()
+	check(arch)
+
+	// the latest ids of voters are rebuilt from the index on start
+	_, err = arch.(*archive).db.Exec("DROP TABLE voter_latest_buckets")
+	require.NoError(err)
+	require.NoError(arch.Stop(ctx))
+	arch, err = NewArchive(cfg, 100, 10)
+	require.NoError(err)
+	require.NoError(arch.Start(ctx))
+	check(arch)
+	require.NoError(arch.PutPoll(150, now, hash.ZeroHash256, []*types.Registration{}, []*types.Bucket{b2, b2}))
+	require.NoError(arch.PutPoll(160, now, hash.ZeroHash256, []*types.Registration{}, []*types.Bucket{b1, b3}))
+	var count int
+	require.NoError(arch.(*archive).db.QueryRow("SELECT COUNT(*) FROM voter_index_buckets WHERE height = 150").Scan(&count))
+	require.Equal(0, count)
+	history, err = arch.VoterBuckets([]byte("voter1"), 150, 160)
+	require.NoError(err)
+	require.Equal(2, len(history))
+	require.True(b1.Equal(history[160][0]))
+	history, err = arch.VoterBuckets([]byte("voter2"), 150, 160)
+	require.NoError(err)
+	require.Equal(1, len(history))
+	// the heights are indexed in order
+	err = arch.PutPoll(160, now, hash.ZeroHash256, []*types.Registration{}, []*types.Bucket{b1})
+	require.Error(err)
+	require.Contains(err.Error(), "not below")
+}
@@ -15,11 +15,13 @@ import (
 	"flag"
 	"io/ioutil"
 	"log"
+	"os"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/iotexproject/iotex-election/committee"
 	"github.com/iotexproject/iotex-election/server"
 )
 
@@ -33,7 +35,16 @@ func main() {
 	}
 	zap.ReplaceGlobals(l)
 	var configPath string
+	var verify, repair bool
+	var insecure bool
+	var exportPath, importPath, snapshotHash string
 	flag.StringVar(&configPath, "config", "server.yaml", "path of server config file")
+	flag.BoolVar(&verify, "verify", false, "verify the consistency of the archive and exit")
+	flag.BoolVar(&repair, "repair", false, "refetch the inconsistent polls to repair the archive in place before start, rolling back from the first one failed to refetch")
+	flag.StringVar(&exportPath, "export-snapshot", "", "export a snapshot of the archive to a file and exit")
+	flag.StringVar(&importPath, "import-snapshot", "", "import a snapshot from a file into an empty archive before start")
+	flag.StringVar(&snapshotHash, "snapshot-hash", "", "trusted content hash of the snapshot to import, which is required unless -insecure")
+	flag.BoolVar(&insecure, "insecure", false, "import a snapshot without a trusted content hash")
 	flag.Parse()
 
 	data, err := ioutil.ReadFile(configPath)
@@ -44,6 +55,25 @@ func main() {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		zap.L().Fatal("failed to unmarshal config", zap.Error(err))
 	}
+	if verify || repair {
+		if err := verifyArchive(config.ElectionConfig, repair); err != nil {
+			zap.L().Fatal("failed to verify archive", zap.Error(err))
+		}
+		if !repair {
+			return
+		}
+	}
+	if exportPath != "" {
+		if err := exportSnapshot(config.ElectionConfig, exportPath); err != nil {
+			zap.L().Fatal("failed to export snapshot", zap.Error(err))
+		}
+		return
+	}
+	if importPath != "" {
+		if err := importSnapshot(config.ElectionConfig, importPath, snapshotHash, insecure); err != nil {
+			zap.L().Fatal("failed to import snapshot", zap.Error(err))
+		}
+	}
 	sm, err := server.NewServerMix(config)
 	if err != nil {
 		zap.L().Fatal("failed to create server", zap.Error(err))
@@ -56,3 +86,100 @@ func main() {
 	defer sm.Stop(context.Background())
 	select {}
 }
+
+func withArchive(cfg server.Config, f func(committee.PollArchive) error) (err error) {
+	ctx := context.Background()
+	archive, err := committee.NewArchive(cfg.DB, cfg.Committee.GravityChainStartHeight, cfg.Committee.GravityChainHeightInterval)
+	if err != nil {
+		return err
+	}
+	if err := archive.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if stopErr := archive.Stop(ctx); err == nil {
+			err = stopErr
+		}
+	}()
+	return f(archive)
+}
+
+func exportSnapshot(cfg server.Config, path string) error {
+	return withArchive(cfg, func(archive committee.PollArchive) (err error) {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		manifest, err := archive.Export(file)
+		if err != nil {
+			return err
+		}
+		zap.L().Info(
+			"snapshot exported",
+			zap.Uint64("lastHeight", manifest.LastHeight),
+			zap.Uint64("numOfPolls", manifest.NumOfPolls),
+			zap.String("contentHash", manifest.ContentHash),
+		)
+		return nil
+	})
+}
+
+func importSnapshot(cfg server.Config, path string, trustedHash string, insecure bool) error {
+	return withArchive(cfg, func(archive committee.PollArchive) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		manifest, err := archive.Import(file, trustedHash, insecure)
+		if err != nil {
+			return err
+		}
+		zap.L().Info(
+			"snapshot imported",
+			zap.Uint64("lastHeight", manifest.LastHeight),
+			zap.Uint64("numOfPolls", manifest.NumOfPolls),
+			zap.String("contentHash", manifest.ContentHash),
+		)
+		return nil
+	})
+}
+
+func verifyArchive(cfg server.Config, repair bool) error {
+	return withArchive(cfg, func(archive committee.PollArchive) error {
+		return verifyAndRepair(archive, cfg.Committee, repair)
+	})
+}
+
+func verifyAndRepair(archive committee.PollArchive, cfg committee.Config, repair bool) error {
+	inconsistencies, err := archive.Verify()
+	if err != nil {
+		return err
+	}
+	for _, inconsistency := range inconsistencies {
+		zap.L().Warn(
+			"inconsistent poll",
+			zap.Uint64("height", inconsistency.Height),
+			zap.Strings("reasons", inconsistency.Reasons),
+		)
+	}
+	zap.L().Info("archive verified", zap.Int("inconsistentHeights", len(inconsistencies)))
+	if !repair || len(inconsistencies) == 0 {
+		return nil
+	}
+	height, err := committee.RepairArchive(archive, cfg, inconsistencies)
+	if err != nil {
+		return err
+	}
+	if height != 0 {
+		zap.L().Info("archive rolled back for refetching", zap.Uint64("height", height))
+		return nil
+	}
+	zap.L().Info("archive repaired", zap.Int("repairedHeights", len(inconsistencies)))
+	return nil
+}
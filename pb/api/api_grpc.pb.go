@@ -39,6 +39,20 @@ type APIServiceClient interface {
 	GetRawData(ctx context.Context, in *GetRawDataRequest, opts ...grpc.CallOption) (*RawDataResponse, error)
 	// get proof for a given account
 	GetProof(ctx context.Context, in *ProofRequest, opts ...grpc.CallOption) (*ProofResponse, error)
+	// get the score overrides in effect
+	GetScoreOverrides(ctx context.Context, in *GetScoreOverridesRequest, opts ...grpc.CallOption) (*ScoreOverrideResponse, error)
+	// get the buckets and weighted votes of a voter across heights
+	GetVoterHistory(ctx context.Context, in *GetVoterHistoryRequest, opts ...grpc.CallOption) (*VoterHistoryResponse, error)
+	// get the score, self staking tokens, rank, and voter count of a candidate across heights
+	GetCandidateHistory(ctx context.Context, in *GetCandidateHistoryRequest, opts ...grpc.CallOption) (*CandidateHistoryResponse, error)
+	// get the difference between the results of two heights
+	GetResultDiff(ctx context.Context, in *GetResultDiffRequest, opts ...grpc.CallOption) (*ResultDiffResponse, error)
+	// subscribe to the results of new heights
+	SubscribeResults(ctx context.Context, in *SubscribeResultsRequest, opts ...grpc.CallOption) (APIService_SubscribeResultsClient, error)
+	// get the commitment of the result of a height, with the inclusion proofs of a candidate and/or a bucket
+	GetResultCommitment(ctx context.Context, in *GetResultCommitmentRequest, opts ...grpc.CallOption) (*ResultCommitmentResponse, error)
+	// get the result of an IoTeX epoch, combining the gravity chain buckets and the native staking buckets
+	GetResultByEpoch(ctx context.Context, in *GetResultByEpochRequest, opts ...grpc.CallOption) (*EpochResultResponse, error)
 }
 
 type aPIServiceClient struct {
@@ -121,6 +135,92 @@ func (c *aPIServiceClient) GetProof(ctx context.Context, in *ProofRequest, opts
 	return out, nil
 }
 
+func (c *aPIServiceClient) GetScoreOverrides(ctx context.Context, in *GetScoreOverridesRequest, opts ...grpc.CallOption) (*ScoreOverrideResponse, error) {
+	out := new(ScoreOverrideResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getScoreOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetVoterHistory(ctx context.Context, in *GetVoterHistoryRequest, opts ...grpc.CallOption) (*VoterHistoryResponse, error) {
+	out := new(VoterHistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getVoterHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetCandidateHistory(ctx context.Context, in *GetCandidateHistoryRequest, opts ...grpc.CallOption) (*CandidateHistoryResponse, error) {
+	out := new(CandidateHistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getCandidateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetResultDiff(ctx context.Context, in *GetResultDiffRequest, opts ...grpc.CallOption) (*ResultDiffResponse, error) {
+	out := new(ResultDiffResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getResultDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SubscribeResults(ctx context.Context, in *SubscribeResultsRequest, opts ...grpc.CallOption) (APIService_SubscribeResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &APIService_ServiceDesc.Streams[0], "/api.APIService/subscribeResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceSubscribeResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_SubscribeResultsClient interface {
+	Recv() (*ResultNotification, error)
+	grpc.ClientStream
+}
+
+type aPIServiceSubscribeResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceSubscribeResultsClient) Recv() (*ResultNotification, error) {
+	m := new(ResultNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetResultCommitment(ctx context.Context, in *GetResultCommitmentRequest, opts ...grpc.CallOption) (*ResultCommitmentResponse, error) {
+	out := new(ResultCommitmentResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getResultCommitment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetResultByEpoch(ctx context.Context, in *GetResultByEpochRequest, opts ...grpc.CallOption) (*EpochResultResponse, error) {
+	out := new(EpochResultResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/getResultByEpoch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServiceServer is the server API for APIService service.
 // All implementations must embed UnimplementedAPIServiceServer
 // for forward compatibility
@@ -141,6 +241,20 @@ type APIServiceServer interface {
 	GetRawData(context.Context, *GetRawDataRequest) (*RawDataResponse, error)
 	// get proof for a given account
 	GetProof(context.Context, *ProofRequest) (*ProofResponse, error)
+	// get the score overrides in effect
+	GetScoreOverrides(context.Context, *GetScoreOverridesRequest) (*ScoreOverrideResponse, error)
+	// get the buckets and weighted votes of a voter across heights
+	GetVoterHistory(context.Context, *GetVoterHistoryRequest) (*VoterHistoryResponse, error)
+	// get the score, self staking tokens, rank, and voter count of a candidate across heights
+	GetCandidateHistory(context.Context, *GetCandidateHistoryRequest) (*CandidateHistoryResponse, error)
+	// get the difference between the results of two heights
+	GetResultDiff(context.Context, *GetResultDiffRequest) (*ResultDiffResponse, error)
+	// subscribe to the results of new heights
+	SubscribeResults(*SubscribeResultsRequest, APIService_SubscribeResultsServer) error
+	// get the commitment of the result of a height, with the inclusion proofs of a candidate and/or a bucket
+	GetResultCommitment(context.Context, *GetResultCommitmentRequest) (*ResultCommitmentResponse, error)
+	// get the result of an IoTeX epoch, combining the gravity chain buckets and the native staking buckets
+	GetResultByEpoch(context.Context, *GetResultByEpochRequest) (*EpochResultResponse, error)
 	mustEmbedUnimplementedAPIServiceServer()
 }
 
@@ -172,6 +286,27 @@ func (UnimplementedAPIServiceServer) GetRawData(context.Context, *GetRawDataRequ
 func (UnimplementedAPIServiceServer) GetProof(context.Context, *ProofRequest) (*ProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
 }
+func (UnimplementedAPIServiceServer) GetScoreOverrides(context.Context, *GetScoreOverridesRequest) (*ScoreOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreOverrides not implemented")
+}
+func (UnimplementedAPIServiceServer) GetVoterHistory(context.Context, *GetVoterHistoryRequest) (*VoterHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoterHistory not implemented")
+}
+func (UnimplementedAPIServiceServer) GetCandidateHistory(context.Context, *GetCandidateHistoryRequest) (*CandidateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidateHistory not implemented")
+}
+func (UnimplementedAPIServiceServer) GetResultDiff(context.Context, *GetResultDiffRequest) (*ResultDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultDiff not implemented")
+}
+func (UnimplementedAPIServiceServer) SubscribeResults(*SubscribeResultsRequest, APIService_SubscribeResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeResults not implemented")
+}
+func (UnimplementedAPIServiceServer) GetResultCommitment(context.Context, *GetResultCommitmentRequest) (*ResultCommitmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultCommitment not implemented")
+}
+func (UnimplementedAPIServiceServer) GetResultByEpoch(context.Context, *GetResultByEpochRequest) (*EpochResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultByEpoch not implemented")
+}
 func (UnimplementedAPIServiceServer) mustEmbedUnimplementedAPIServiceServer() {}
 
 // UnsafeAPIServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -329,6 +464,135 @@ func _APIService_GetProof_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_GetScoreOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetScoreOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getScoreOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetScoreOverrides(ctx, req.(*GetScoreOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetVoterHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoterHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetVoterHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getVoterHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetVoterHistory(ctx, req.(*GetVoterHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetCandidateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetCandidateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getCandidateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetCandidateHistory(ctx, req.(*GetCandidateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetResultDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetResultDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getResultDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetResultDiff(ctx, req.(*GetResultDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_SubscribeResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).SubscribeResults(m, &aPIServiceSubscribeResultsServer{stream})
+}
+
+type APIService_SubscribeResultsServer interface {
+	Send(*ResultNotification) error
+	grpc.ServerStream
+}
+
+type aPIServiceSubscribeResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceSubscribeResultsServer) Send(m *ResultNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetResultCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetResultCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getResultCommitment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetResultCommitment(ctx, req.(*GetResultCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetResultByEpoch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultByEpochRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetResultByEpoch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/getResultByEpoch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetResultByEpoch(ctx, req.(*GetResultByEpochRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // APIService_ServiceDesc is the grpc.ServiceDesc for APIService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -368,7 +632,37 @@ var APIService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "getProof",
 			Handler:    _APIService_GetProof_Handler,
 		},
+		{
+			MethodName: "getScoreOverrides",
+			Handler:    _APIService_GetScoreOverrides_Handler,
+		},
+		{
+			MethodName: "getVoterHistory",
+			Handler:    _APIService_GetVoterHistory_Handler,
+		},
+		{
+			MethodName: "getCandidateHistory",
+			Handler:    _APIService_GetCandidateHistory_Handler,
+		},
+		{
+			MethodName: "getResultDiff",
+			Handler:    _APIService_GetResultDiff_Handler,
+		},
+		{
+			MethodName: "getResultCommitment",
+			Handler:    _APIService_GetResultCommitment_Handler,
+		},
+		{
+			MethodName: "getResultByEpoch",
+			Handler:    _APIService_GetResultByEpoch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "subscribeResults",
+			Handler:       _APIService_SubscribeResults_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/api.proto",
 }
@@ -572,6 +572,180 @@ func (x *ElectionResult) GetTotalVotes() []byte {
 	return nil
 }
 
+type SnapshotPoll struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height                 uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	MintTime               *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=mintTime,proto3" json:"mintTime,omitempty"`
+	BlockHash              []byte                 `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Poll                   *Poll                  `protobuf:"bytes,4,opt,name=poll,proto3" json:"poll,omitempty"`
+	IdenticalRegistrations bool                   `protobuf:"varint,5,opt,name=identicalRegistrations,proto3" json:"identicalRegistrations,omitempty"`
+	IdenticalBuckets       bool                   `protobuf:"varint,6,opt,name=identicalBuckets,proto3" json:"identicalBuckets,omitempty"`
+}
+
+func (x *SnapshotPoll) Reset() {
+	*x = SnapshotPoll{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_election_election_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotPoll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotPoll) ProtoMessage() {}
+
+func (x *SnapshotPoll) ProtoReflect() protoreflect.Message {
+	mi := &file_election_election_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotPoll.ProtoReflect.Descriptor instead.
+func (*SnapshotPoll) Descriptor() ([]byte, []int) {
+	return file_election_election_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SnapshotPoll) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SnapshotPoll) GetMintTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MintTime
+	}
+	return nil
+}
+
+func (x *SnapshotPoll) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *SnapshotPoll) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *SnapshotPoll) GetIdenticalRegistrations() bool {
+	if x != nil {
+		return x.IdenticalRegistrations
+	}
+	return false
+}
+
+func (x *SnapshotPoll) GetIdenticalBuckets() bool {
+	if x != nil {
+		return x.IdenticalBuckets
+	}
+	return false
+}
+
+type SnapshotManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version     uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	StartHeight uint64 `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	Interval    uint64 `protobuf:"varint,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	LastHeight  uint64 `protobuf:"varint,4,opt,name=lastHeight,proto3" json:"lastHeight,omitempty"`
+	NumOfPolls  uint64 `protobuf:"varint,5,opt,name=numOfPolls,proto3" json:"numOfPolls,omitempty"`
+	ContentHash []byte `protobuf:"bytes,6,opt,name=contentHash,proto3" json:"contentHash,omitempty"`
+}
+
+func (x *SnapshotManifest) Reset() {
+	*x = SnapshotManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_election_election_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotManifest) ProtoMessage() {}
+
+func (x *SnapshotManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_election_election_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotManifest.ProtoReflect.Descriptor instead.
+func (*SnapshotManifest) Descriptor() ([]byte, []int) {
+	return file_election_election_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SnapshotManifest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetInterval() uint64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetLastHeight() uint64 {
+	if x != nil {
+		return x.LastHeight
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetNumOfPolls() uint64 {
+	if x != nil {
+		return x.NumOfPolls
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetContentHash() []byte {
+	if x != nil {
+		return x.ContentHash
+	}
+	return nil
+}
+
 var File_election_election_proto protoreflect.FileDescriptor
 
 var file_election_election_proto_rawDesc = []byte{
@@ -663,10 +837,40 @@ var file_election_election_proto_rawDesc = []byte{
 	0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x6b, 0x65,
 	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x18,
 	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65,
-	0x73, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74,
-	0x65, 0x78, 0x2d, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x22, 0x84, 0x02, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x6f,
+	0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x6d, 0x69,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x22, 0x0a, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x04,
+	0x70, 0x6f, 0x6c, 0x6c, 0x12, 0x36, 0x0a, 0x16, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x6c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x10,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x6c, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x10, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x4f, 0x66, 0x50, 0x6f,
+	0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x4f, 0x66,
+	0x50, 0x6f, 0x6c, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -681,7 +885,7 @@ func file_election_election_proto_rawDescGZIP() []byte {
 	return file_election_election_proto_rawDescData
 }
 
-var file_election_election_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_election_election_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_election_election_proto_goTypes = []interface{}{
 	(*Bucket)(nil),                // 0: election.Bucket
 	(*Registration)(nil),          // 1: election.Registration
@@ -690,23 +894,27 @@ var file_election_election_proto_goTypes = []interface{}{
 	(*VoteList)(nil),              // 4: election.VoteList
 	(*Candidate)(nil),             // 5: election.Candidate
 	(*ElectionResult)(nil),        // 6: election.ElectionResult
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 8: google.protobuf.Duration
+	(*SnapshotPoll)(nil),          // 7: election.SnapshotPoll
+	(*SnapshotManifest)(nil),      // 8: election.SnapshotManifest
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 10: google.protobuf.Duration
 }
 var file_election_election_proto_depIdxs = []int32{
-	7, // 0: election.Bucket.startTime:type_name -> google.protobuf.Timestamp
-	8, // 1: election.Bucket.duration:type_name -> google.protobuf.Duration
-	7, // 2: election.Vote.startTime:type_name -> google.protobuf.Timestamp
-	8, // 3: election.Vote.duration:type_name -> google.protobuf.Duration
-	3, // 4: election.VoteList.votes:type_name -> election.Vote
-	7, // 5: election.ElectionResult.timestamp:type_name -> google.protobuf.Timestamp
-	5, // 6: election.ElectionResult.delegates:type_name -> election.Candidate
-	4, // 7: election.ElectionResult.delegateVotes:type_name -> election.VoteList
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	9,  // 0: election.Bucket.startTime:type_name -> google.protobuf.Timestamp
+	10, // 1: election.Bucket.duration:type_name -> google.protobuf.Duration
+	9,  // 2: election.Vote.startTime:type_name -> google.protobuf.Timestamp
+	10, // 3: election.Vote.duration:type_name -> google.protobuf.Duration
+	3,  // 4: election.VoteList.votes:type_name -> election.Vote
+	9,  // 5: election.ElectionResult.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 6: election.ElectionResult.delegates:type_name -> election.Candidate
+	4,  // 7: election.ElectionResult.delegateVotes:type_name -> election.VoteList
+	9,  // 8: election.SnapshotPoll.mintTime:type_name -> google.protobuf.Timestamp
+	2,  // 9: election.SnapshotPoll.poll:type_name -> election.Poll
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_election_election_proto_init() }
@@ -799,6 +1007,30 @@ func file_election_election_proto_init() {
 				return nil
 			}
 		}
+		file_election_election_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotPoll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_election_election_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -806,7 +1038,7 @@ func file_election_election_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_election_election_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -144,3 +144,38 @@ func (s *dummyServer) GetProof(ctx context.Context, request *api.ProofRequest) (
 	zap.L().Info("Dummpy server calls GetProof func")
 	return nil, nil
 }
+
+func (s *dummyServer) GetScoreOverrides(ctx context.Context, request *api.GetScoreOverridesRequest) (*api.ScoreOverrideResponse, error) {
+	zap.L().Info("Dummpy server calls GetScoreOverrides func")
+	return nil, nil
+}
+
+func (s *dummyServer) GetVoterHistory(ctx context.Context, request *api.GetVoterHistoryRequest) (*api.VoterHistoryResponse, error) {
+	zap.L().Info("Dummpy server calls GetVoterHistory func")
+	return nil, nil
+}
+
+func (s *dummyServer) GetCandidateHistory(ctx context.Context, request *api.GetCandidateHistoryRequest) (*api.CandidateHistoryResponse, error) {
+	zap.L().Info("Dummpy server calls GetCandidateHistory func")
+	return nil, nil
+}
+
+func (s *dummyServer) GetResultDiff(ctx context.Context, request *api.GetResultDiffRequest) (*api.ResultDiffResponse, error) {
+	zap.L().Info("Dummpy server calls GetResultDiff func")
+	return nil, nil
+}
+
+func (s *dummyServer) SubscribeResults(request *api.SubscribeResultsRequest, stream api.APIService_SubscribeResultsServer) error {
+	zap.L().Info("Dummpy server calls SubscribeResults func")
+	return nil
+}
+
+func (s *dummyServer) GetResultCommitment(ctx context.Context, request *api.GetResultCommitmentRequest) (*api.ResultCommitmentResponse, error) {
+	zap.L().Info("Dummpy server calls GetResultCommitment func")
+	return nil, nil
+}
+
+func (s *dummyServer) GetResultByEpoch(ctx context.Context, request *api.GetResultByEpochRequest) (*api.EpochResultResponse, error) {
+	zap.L().Info("Dummpy server calls GetResultByEpoch func")
+	return nil, nil
+}
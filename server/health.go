@@ -0,0 +1,111 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/iotexproject/iotex-election/carrier"
+	"github.com/iotexproject/iotex-election/committee"
+	"github.com/iotexproject/iotex-election/pb/api"
+)
+
+// urlPattern matches the urls in error messages, e.g., the ones of failed http requests, without trailing punctuation
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"']*[^\s"':,.;)]`)
+
+// redactURLs redacts the urls in s, as the health is served to unauthenticated callers and the endpoint urls may carry
+// api keys
+func redactURLs(s string) string {
+	return urlPattern.ReplaceAllStringFunc(s, carrier.RedactURL)
+}
+
+func toHealthCheckResponse(health *committee.Health) (*api.HealthCheckResponse, error) {
+	var status api.HealthCheckResponse_Status
+	switch health.Status {
+	case committee.STARTING:
+		status = api.HealthCheckResponse_STARTING
+	case committee.ACTIVE:
+		status = api.HealthCheckResponse_ACTIVE
+	case committee.INACTIVE:
+		status = api.HealthCheckResponse_INACTIVE
+	}
+	response := &api.HealthCheckResponse{
+		Status:         status,
+		Live:           health.Live,
+		Ready:          health.Ready,
+		ArchivedHeight: strconv.FormatUint(health.ArchivedHeight, 10),
+		TipHeight:      strconv.FormatUint(health.TipHeight, 10),
+		LagBlocks:      strconv.FormatUint(health.LagBlocks, 10),
+		LagSeconds:     uint64(health.LagTime.Seconds()),
+		LastError:      redactURLs(health.LastError),
+		Endpoints:      make([]*api.EndpointHealth, 0, len(health.Endpoints)),
+	}
+	var err error
+	if !health.LastErrorTime.IsZero() {
+		if response.LastErrorTime, err = ptypes.TimestampProto(health.LastErrorTime); err != nil {
+			return nil, err
+		}
+	}
+	for _, endpoint := range health.Endpoints {
+		eh := &api.EndpointHealth{
+			Url:       carrier.RedactURL(endpoint.URL),
+			Reachable: endpoint.Reachable,
+			LastError: redactURLs(endpoint.LastError),
+		}
+		if !endpoint.LastCheck.IsZero() {
+			if eh.LastCheckTime, err = ptypes.TimestampProto(endpoint.LastCheck); err != nil {
+				return nil, err
+			}
+		}
+		response.Endpoints = append(response.Endpoints, eh)
+	}
+	return response, nil
+}
+
+// probeHandler responds the health in json, with status 200 if the probe passes, and 503 otherwise
+func probeHandler(health func() *committee.Health, pass func(*committee.Health) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h := health()
+		response, err := toHealthCheckResponse(h)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, err := protojson.Marshal(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if pass(h) {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if _, err := w.Write(data); err != nil {
+			zap.L().Error("failed to write probe response", zap.Error(err))
+		}
+	}
+}
+
+func livenessProbe(health func() *committee.Health) http.HandlerFunc {
+	return probeHandler(health, func(h *committee.Health) bool { return h.Live })
+}
+
+func readinessProbe(health func() *committee.Health) http.HandlerFunc {
+	return probeHandler(health, func(h *committee.Health) bool { return h.Ready })
+}
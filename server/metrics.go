@@ -0,0 +1,85 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-election/committee"
+)
+
+var grpcDurationMtc = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "iotex_election_grpc_duration_seconds",
+		Help:    "Latency of the gRPC calls by server, method, and status code",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"server", "method", "code"},
+)
+
+func init() {
+	prometheus.MustRegister(grpcDurationMtc)
+}
+
+// newGRPCServer creates a grpc server recording the latency of the calls with the server label
+func newGRPCServer(label string) *grpc.Server {
+	return grpc.NewServer(
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			grpcDurationMtc.WithLabelValues(label, info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+			return resp, err
+		}),
+		grpc.StreamInterceptor(func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			start := time.Now()
+			err := handler(srv, ss)
+			grpcDurationMtc.WithLabelValues(label, info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+			return err
+		}),
+	)
+}
+
+// startMetricsServer serves the prometheus metrics at /metrics, and the liveness and readiness probes at /healthz and
+// /readyz on port
+func startMetricsServer(port int, health func() *committee.Health) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/healthz", livenessProbe(health))
+	mux.Handle("/readyz", readinessProbe(health))
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil {
+			zap.L().Panic("failed to serve metrics", zap.Error(err))
+		}
+	}()
+}
@@ -11,6 +11,7 @@
 package server
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -18,6 +19,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -44,6 +46,7 @@ type Config struct {
 	DB                   db.Config        `yaml:"db"`
 	Port                 int              `yaml:"port"`
 	HttpPort             int              `yaml:"httpPort"`
+	MetricsPort          int              `yaml:"metricsPort"`
 	Committee            committee.Config `yaml:"committee"`
 	SelfStakingThreshold string           `yaml:"selfStakingThreshold"`
 	ScoreThreshold       string           `yaml:"scoreThreshold"`
@@ -60,16 +63,20 @@ type Server interface {
 type server struct {
 	api.UnimplementedAPIServiceServer
 	port                 int
-	electionCommittee    committee.Committee
+	electionCommittee    committee.Provider
 	grpcServer           *grpc.Server
 	selfStakingThreshold *big.Int
 	scoreThreshold       *big.Int
 	vs                   *votesync.VoteSync
+	startHeight          uint64
+	interval             uint64
+	// candidateName parses the candidate name in the requests
+	candidateName func(string) ([]byte, error)
 }
 
 // NewServer returns an implementation of ranking server
 func NewServer(cfg *Config, vs *votesync.VoteSync) (Server, error) {
-	archive, err := committee.NewArchive(cfg.DB.DBPath, cfg.DB.NumOfRetries, cfg.Committee.GravityChainStartHeight, cfg.Committee.GravityChainHeightInterval)
+	archive, err := committee.NewArchive(cfg.DB, cfg.Committee.GravityChainStartHeight, cfg.Committee.GravityChainHeightInterval)
 	if err != nil {
 		return nil, err
 	}
@@ -91,18 +98,43 @@ func NewServer(cfg *Config, vs *votesync.VoteSync) (Server, error) {
 		scoreThreshold:       scoreThreshold,
 		selfStakingThreshold: selfStakingThreshold,
 		vs:                   vs,
+		startHeight:          cfg.Committee.GravityChainStartHeight,
+		interval:             cfg.Committee.GravityChainHeightInterval,
+		candidateName:        hex.DecodeString,
 	}
-	s.grpcServer = grpc.NewServer()
-	api.RegisterAPIServiceServer(s.grpcServer, s)
+	s.register(s, "election", cfg.HttpPort, cfg.MetricsPort)
+
+	return s, nil
+}
+
+// register registers the api implementation to a grpc server with the metrics label, and serves the metrics and the
+// http gateway if the ports are set
+func (s *server) register(impl api.APIServiceServer, label string, httpPort int, metricsPort int) {
+	s.grpcServer = newGRPCServer(label)
+	api.RegisterAPIServiceServer(s.grpcServer, impl)
 	reflection.Register(s.grpcServer)
-	if cfg.HttpPort > 0 {
+	if metricsPort > 0 {
+		startMetricsServer(metricsPort, s.electionCommittee.Health)
+	}
+	if httpPort > 0 {
 		go func() {
 			gwmux := runtime.NewServeMux()
-			if err := api.RegisterAPIServiceHandlerServer(context.Background(), gwmux, s); err != nil {
+			if err := api.RegisterAPIServiceHandlerServer(context.Background(), gwmux, impl); err != nil {
 				zap.L().Panic("failed to register api server")
 			}
+			for path, probe := range map[string]http.HandlerFunc{
+				"/healthz": livenessProbe(s.electionCommittee.Health),
+				"/readyz":  readinessProbe(s.electionCommittee.Health),
+			} {
+				probe := probe
+				if err := gwmux.HandlePath("GET", path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					probe(w, r)
+				}); err != nil {
+					zap.L().Panic("failed to register probe", zap.String("path", path), zap.Error(err))
+				}
+			}
 			gwServer := &http.Server{
-				Addr:    fmt.Sprintf(":%d", cfg.HttpPort),
+				Addr:    fmt.Sprintf(":%d", httpPort),
 				Handler: gwmux,
 			}
 			if err := gwServer.ListenAndServe(); err != nil {
@@ -110,8 +142,6 @@ func NewServer(cfg *Config, vs *votesync.VoteSync) (Server, error) {
 			}
 		}()
 	}
-
-	return s, nil
 }
 
 func (s *server) Start(ctx context.Context) error {
@@ -151,9 +181,14 @@ func (s *server) GetMeta(ctx context.Context, empty *empty.Empty) (*api.ChainMet
 	if err != nil {
 		return &api.ChainMeta{}, err
 	}
+
+	return toChainMeta(height, result, s.scoreThreshold, s.selfStakingThreshold), nil
+}
+
+func toChainMeta(height uint64, result *types.ElectionResult, scoreThreshold, selfStakingThreshold *big.Int) *api.ChainMeta {
 	numOfCandidates := uint64(0)
 	for _, d := range result.Delegates() {
-		if d.Score().Cmp(s.scoreThreshold) >= 0 && d.SelfStakingTokens().Cmp(s.selfStakingThreshold) >= 0 {
+		if d.Score().Cmp(scoreThreshold) >= 0 && d.SelfStakingTokens().Cmp(selfStakingThreshold) >= 0 {
 			numOfCandidates++
 		}
 	}
@@ -163,22 +198,11 @@ func (s *server) GetMeta(ctx context.Context, empty *empty.Empty) (*api.ChainMet
 		TotalCandidates:  numOfCandidates,
 		TotalVotedStakes: result.TotalVotedStakes().Text(10),
 		TotalVotes:       result.TotalVotes().Text(10),
-	}, nil
+	}
 }
 
 func (s *server) IsHealth(ctx context.Context, empty *empty.Empty) (*api.HealthCheckResponse, error) {
-	var status api.HealthCheckResponse_Status
-	switch s.electionCommittee.Status() {
-	case committee.STARTING:
-		status = api.HealthCheckResponse_STARTING
-	case committee.ACTIVE:
-		status = api.HealthCheckResponse_ACTIVE
-	case committee.INACTIVE:
-		status = api.HealthCheckResponse_INACTIVE
-	}
-	return &api.HealthCheckResponse{
-		Status: status,
-	}, nil
+	return toHealthCheckResponse(s.electionCommittee.Health())
 }
 
 // GetCandidates returns a list of candidates sorted by weighted votes
@@ -191,12 +215,15 @@ func (s *server) GetCandidates(ctx context.Context, request *api.GetCandidatesRe
 	if err != nil {
 		return nil, err
 	}
+
+	return toCandidateResponse(result, request.Offset, request.Limit)
+}
+
+func toCandidateResponse(result *types.ElectionResult, offset uint32, limit uint32) (*api.CandidateResponse, error) {
 	candidates := result.Delegates()
-	offset := request.Offset
 	if len(candidates) <= int(offset) {
 		return nil, errors.New("offset is larger than candidate length")
 	}
-	limit := request.Limit
 	// If limit is missing, return all candidates with indices starting from the offset
 	if limit == uint32(0) {
 		limit = math.MaxUint32
@@ -208,32 +235,35 @@ func (s *server) GetCandidates(ctx context.Context, request *api.GetCandidatesRe
 		Candidates: make([]*api.Candidate, limit),
 	}
 	for i := uint32(0); i < limit; i++ {
-		candidate := candidates[offset+i]
-		var ra string
-		var oa string
-		if util.IsAllZeros(candidate.RewardAddress()) {
-			ra = ""
-		} else {
-			ra = string(candidate.RewardAddress())
-		}
-		if util.IsAllZeros(candidate.OperatorAddress()) {
-			oa = ""
-		} else {
-			oa = string(candidate.OperatorAddress())
-		}
-		response.Candidates[i] = &api.Candidate{
-			Name:               hex.EncodeToString(candidate.Name()),
-			Address:            hex.EncodeToString(candidate.Address()),
-			RewardAddress:      ra,
-			OperatorAddress:    oa,
-			TotalWeightedVotes: candidate.Score().Text(10),
-			SelfStakingTokens:  candidate.SelfStakingTokens().Text(10),
-		}
+		response.Candidates[i] = toCandidate(candidates[offset+i])
 	}
 
 	return response, nil
 }
 
+func toCandidate(candidate *types.Candidate) *api.Candidate {
+	var ra string
+	var oa string
+	if util.IsAllZeros(candidate.RewardAddress()) {
+		ra = ""
+	} else {
+		ra = string(candidate.RewardAddress())
+	}
+	if util.IsAllZeros(candidate.OperatorAddress()) {
+		oa = ""
+	} else {
+		oa = string(candidate.OperatorAddress())
+	}
+	return &api.Candidate{
+		Name:               hex.EncodeToString(candidate.Name()),
+		Address:            hex.EncodeToString(candidate.Address()),
+		RewardAddress:      ra,
+		OperatorAddress:    oa,
+		TotalWeightedVotes: candidate.Score().Text(10),
+		SelfStakingTokens:  candidate.SelfStakingTokens().Text(10),
+	}
+}
+
 // GetCandidateByName returns the candidate details
 func (s *server) GetCandidateByName(ctx context.Context, request *api.GetCandidateByNameRequest) (*api.Candidate, error) {
 	height, err := strconv.ParseUint(request.Height, 10, 64)
@@ -244,7 +274,7 @@ func (s *server) GetCandidateByName(ctx context.Context, request *api.GetCandida
 	if err != nil {
 		return nil, err
 	}
-	name, err := hex.DecodeString(request.Name)
+	name, err := s.candidateName(request.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -272,9 +302,11 @@ func (s *server) GetBucketsByCandidate(ctx context.Context, request *api.GetBuck
 	if err != nil {
 		return nil, err
 	}
-	name, err := hex.DecodeString(request.Name)
-	if err != nil {
-		return nil, err
+	var name []byte
+	if request.Name != "" {
+		if name, err = s.candidateName(request.Name); err != nil {
+			return nil, err
+		}
 	}
 	var votes []*types.Vote
 	switch len(name) {
@@ -293,10 +325,10 @@ func (s *server) GetBucketsByCandidate(ctx context.Context, request *api.GetBuck
 		return nil, errors.New("offset is out of range")
 	}
 
-	return s.toBucketResponse(votes, offset, request.Limit, result.MintTime()), nil
+	return toBucketResponse(votes, offset, request.Limit, result.MintTime()), nil
 }
 
-func (s *server) toBucketResponse(votes []*types.Vote, offset uint32, limit uint32, mintTime time.Time) *api.BucketResponse {
+func toBucketResponse(votes []*types.Vote, offset uint32, limit uint32, mintTime time.Time) *api.BucketResponse {
 	// If limit is missing, return all buckets with indices starting from the offset
 	if limit == uint32(0) {
 		limit = math.MaxUint32
@@ -338,7 +370,7 @@ func (s *server) GetBuckets(ctx context.Context, request *api.GetBucketsRequest)
 		return nil, errors.New("offset is out of range")
 	}
 
-	return s.toBucketResponse(votes, offset, request.Limit, result.MintTime()), nil
+	return toBucketResponse(votes, offset, request.Limit, result.MintTime()), nil
 }
 
 func (s *server) GetRawData(ctx context.Context, request *api.GetRawDataRequest) (*api.RawDataResponse, error) {
@@ -350,10 +382,10 @@ func (s *server) GetRawData(ctx context.Context, request *api.GetRawDataRequest)
 	if err != nil {
 		return nil, err
 	}
-	return s.toRawDataResponse(timestamp, regs, buckets)
+	return toRawDataResponse(timestamp, regs, buckets)
 }
 
-func (s *server) toRawDataResponse(mintTime time.Time, regs []*types.Registration, buckets []*types.Bucket) (*api.RawDataResponse, error) {
+func toRawDataResponse(mintTime time.Time, regs []*types.Registration, buckets []*types.Bucket) (*api.RawDataResponse, error) {
 	response := &api.RawDataResponse{
 		Buckets:       make([]*electionpb.Bucket, len(buckets)),
 		Registrations: make([]*electionpb.Registration, len(regs)),
@@ -382,14 +414,18 @@ func (s *server) toRawDataResponse(mintTime time.Time, regs []*types.Registratio
 }
 
 func (s *server) GetProof(ctx context.Context, request *api.ProofRequest) (*api.ProofResponse, error) {
-	if s.vs == nil {
+	return toProofResponse(s.vs, request.Account)
+}
+
+func toProofResponse(vs *votesync.VoteSync, account string) (*api.ProofResponse, error) {
+	if vs == nil {
 		return nil, errors.New("no vote sync server")
 	}
-	addr, err := address.FromString(request.Account)
+	addr, err := address.FromString(account)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to cast address %s", request.Account)
+		return nil, errors.Wrapf(err, "failed to cast address %s", account)
 	}
-	cycle, amount, proof, err := s.vs.ProofForAccount(addr)
+	cycle, amount, proof, err := vs.ProofForAccount(addr)
 	if err != nil {
 		return nil, err
 	}
@@ -403,3 +439,447 @@ func (s *server) GetProof(ctx context.Context, request *api.ProofRequest) (*api.
 		Proof:    hex.EncodeToString(proof),
 	}, nil
 }
+
+// GetScoreOverrides returns the score overrides applied on the election results, optionally filtered by height
+func (s *server) GetScoreOverrides(ctx context.Context, request *api.GetScoreOverridesRequest) (*api.ScoreOverrideResponse, error) {
+	var height uint64
+	if request.Height != "" {
+		var err error
+		if height, err = strconv.ParseUint(request.Height, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	response := &api.ScoreOverrideResponse{}
+	for _, o := range s.electionCommittee.ScoreOverrides() {
+		if request.Height != "" && o.Height != height {
+			continue
+		}
+		response.Overrides = append(response.Overrides, &api.ScoreOverride{
+			Height:            strconv.FormatUint(o.Height, 10),
+			Name:              o.CandidateName,
+			Score:             o.Score,
+			SelfStakingTokens: o.SelfStakingTokens,
+		})
+	}
+	return response, nil
+}
+
+// GetVoterHistory returns the buckets and weighted votes of a voter at the heights in a range
+func (s *server) GetVoterHistory(ctx context.Context, request *api.GetVoterHistoryRequest) (*api.VoterHistoryResponse, error) {
+	voter, err := hex.DecodeString(strings.TrimPrefix(request.Voter, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(voter) == 0 {
+		return nil, errors.New("empty voter")
+	}
+	startHeight, err := parseHeight(request.StartHeight, 0)
+	if err != nil {
+		return nil, err
+	}
+	endHeight, err := parseHeight(request.EndHeight, s.electionCommittee.LatestHeight())
+	if err != nil {
+		return nil, err
+	}
+	polls, err := s.electionCommittee.VoterHistory(voter, startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVoterHistoryResponse(polls)
+}
+
+// parseHeight parses an optional height, returning defaultHeight if it is empty
+func parseHeight(height string, defaultHeight uint64) (uint64, error) {
+	if height == "" {
+		return defaultHeight, nil
+	}
+	return strconv.ParseUint(height, 10, 64)
+}
+
+func toVoterHistoryResponse(polls []*committee.VoterPoll) (*api.VoterHistoryResponse, error) {
+	response := &api.VoterHistoryResponse{
+		Polls: make([]*api.VoterPoll, 0, len(polls)),
+	}
+	for _, poll := range polls {
+		t, err := ptypes.TimestampProto(poll.MintTime)
+		if err != nil {
+			return nil, err
+		}
+		pollPb := &api.VoterPoll{
+			Height:    strconv.FormatUint(poll.Height, 10),
+			Timestamp: t,
+			Buckets:   make([]*api.VoterBucket, 0, len(poll.Votes)),
+		}
+		total := big.NewInt(0)
+		candidates := map[string]bool{}
+		for _, vote := range poll.Votes {
+			candidate := hex.EncodeToString(vote.Candidate())
+			if !candidates[candidate] {
+				candidates[candidate] = true
+				pollPb.Candidates = append(pollPb.Candidates, candidate)
+			}
+			total.Add(total, vote.WeightedAmount())
+			pollPb.Buckets = append(pollPb.Buckets, &api.VoterBucket{
+				Candidate:         candidate,
+				Votes:             vote.Amount().Text(10),
+				WeightedVotes:     vote.WeightedAmount().Text(10),
+				RemainingDuration: vote.RemainingTime(poll.MintTime).String(),
+			})
+		}
+		pollPb.TotalWeightedVotes = total.Text(10)
+		response.Polls = append(response.Polls, pollPb)
+	}
+	return response, nil
+}
+
+// GetCandidateHistory returns the stats of a candidate at the heights in a range
+func (s *server) GetCandidateHistory(ctx context.Context, request *api.GetCandidateHistoryRequest) (*api.CandidateHistoryResponse, error) {
+	name, err := s.candidateName(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(name) != 12 {
+		return nil, errors.New("invalid candidate name")
+	}
+	startHeight, err := parseHeight(request.StartHeight, 0)
+	if err != nil {
+		return nil, err
+	}
+	endHeight, err := parseHeight(request.EndHeight, s.electionCommittee.LatestHeight())
+	if err != nil {
+		return nil, err
+	}
+	step, err := parseHeight(request.Step, 0)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.electionCommittee.CandidateHistory(name, startHeight, endHeight, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCandidateHistoryResponse(points)
+}
+
+func toCandidateHistoryResponse(points []*committee.CandidatePoint) (*api.CandidateHistoryResponse, error) {
+	response := &api.CandidateHistoryResponse{
+		Points: make([]*api.CandidatePoint, 0, len(points)),
+	}
+	for _, point := range points {
+		t, err := ptypes.TimestampProto(point.MintTime)
+		if err != nil {
+			return nil, err
+		}
+		response.Points = append(response.Points, &api.CandidatePoint{
+			Height:             strconv.FormatUint(point.Height, 10),
+			Timestamp:          t,
+			Rank:               uint32(point.Rank),
+			TotalWeightedVotes: point.Score.Text(10),
+			SelfStakingTokens:  point.SelfStakingTokens.Text(10),
+			VoterCount:         uint64(point.VoterCount),
+		})
+	}
+	return response, nil
+}
+
+// GetResultDiff returns the difference between the results of two heights
+func (s *server) GetResultDiff(ctx context.Context, request *api.GetResultDiffRequest) (*api.ResultDiffResponse, error) {
+	startHeight, err := strconv.ParseUint(request.StartHeight, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	endHeight, err := strconv.ParseUint(request.EndHeight, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.electionCommittee.ResultByHeight(startHeight)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.electionCommittee.ResultByHeight(endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResultDiffResponse(before, after)
+}
+
+func toResultDiffResponse(before, after *types.ElectionResult) (*api.ResultDiffResponse, error) {
+	diff, err := types.DiffResults(before, after)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ResultDiffResponse{
+		EnteredCandidates: toCandidateDiffs(diff.EnteredCandidates),
+		LeftCandidates:    toCandidateDiffs(diff.LeftCandidates),
+		ChangedCandidates: toCandidateDiffs(diff.ChangedCandidates),
+		AddedBuckets:      toBucketDiffs(diff.AddedVotes),
+		RemovedBuckets:    toBucketDiffs(diff.RemovedVotes),
+		ChangedBuckets:    toBucketDiffs(diff.ChangedVotes),
+	}, nil
+}
+
+func toCandidateDiffs(diffs []*types.CandidateDiff) []*api.CandidateDiff {
+	retval := make([]*api.CandidateDiff, 0, len(diffs))
+	for _, d := range diffs {
+		retval = append(retval, &api.CandidateDiff{
+			Name:        hex.EncodeToString(d.Name),
+			RankBefore:  uint32(d.RankBefore),
+			RankAfter:   uint32(d.RankAfter),
+			ScoreBefore: d.ScoreBefore.Text(10),
+			ScoreAfter:  d.ScoreAfter.Text(10),
+			ScoreDelta:  d.ScoreDelta().Text(10),
+		})
+	}
+	return retval
+}
+
+func toBucketDiffs(diffs []*types.VoteDiff) []*api.BucketDiff {
+	retval := make([]*api.BucketDiff, 0, len(diffs))
+	for _, d := range diffs {
+		bd := &api.BucketDiff{Hash: hex.EncodeToString(d.Hash[:])}
+		vote := d.After
+		if d.Before != nil {
+			vote = d.Before
+			bd.WeightedVotesBefore = d.Before.WeightedAmount().Text(10)
+		}
+		if d.After != nil {
+			bd.WeightedVotesAfter = d.After.WeightedAmount().Text(10)
+		}
+		bd.Voter = hex.EncodeToString(vote.Voter())
+		bd.Candidate = hex.EncodeToString(vote.Candidate())
+		bd.Votes = vote.Amount().Text(10)
+		retval = append(retval, bd)
+	}
+	return retval
+}
+
+// SubscribeResults streams the results of new heights. A client resuming from a height receives the results from that
+// height on first. The results are read from the archive at the pace of the client, so a slow client only delays
+// itself. The heights rolled back due to chain reorganization are streamed again once refetched.
+func (s *server) SubscribeResults(request *api.SubscribeResultsRequest, stream api.APIService_SubscribeResultsServer) error {
+	heights, cancel := s.electionCommittee.SubscribeHeights()
+	defer cancel()
+
+	return streamResults(
+		request,
+		stream,
+		heights,
+		s.startHeight,
+		s.interval,
+		s.electionCommittee.LatestHeight,
+		s.electionCommittee.ResultByHeight,
+	)
+}
+
+// streamResults sends the results from the requested height on, and waits for the stored heights to send more
+func streamResults(
+	request *api.SubscribeResultsRequest,
+	stream api.APIService_SubscribeResultsServer,
+	heights <-chan uint64,
+	startHeight uint64,
+	interval uint64,
+	latestHeight func() uint64,
+	resultByHeight func(uint64) (*types.ElectionResult, error),
+) error {
+	next, err := parseHeight(request.StartHeight, latestHeight()+interval)
+	if err != nil {
+		return err
+	}
+	if next < startHeight {
+		next = startHeight
+	}
+	if offset := (next - startHeight) % interval; offset != 0 {
+		next += interval - offset
+	}
+	for {
+		latest := latestHeight()
+		for ; next <= latest; next += interval {
+			result, err := resultByHeight(next)
+			if err != nil {
+				if next <= latestHeight() && errors.Cause(err) != db.ErrNotExist {
+					return err
+				}
+				// the height is rolled back meanwhile, and is sent again once the notifier reports it stored
+				break
+			}
+			notification, err := toResultNotification(next, result, request.TopN)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(notification); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case height := <-heights:
+			if height < next && height >= startHeight {
+				// resend the heights changed
+				next = height
+			}
+		}
+	}
+}
+
+// GetResultCommitment returns the commitment of the result of a height. With a candidate name or a bucket hash in the
+// request, the inclusion proofs against the delegates root or the votes root are returned as well.
+func (s *server) GetResultCommitment(ctx context.Context, request *api.GetResultCommitmentRequest) (*api.ResultCommitmentResponse, error) {
+	height, err := strconv.ParseUint(request.Height, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.electionCommittee.ResultByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	var name []byte
+	if request.Name != "" {
+		if name, err = s.candidateName(request.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return toResultCommitmentResponse(request, result, name)
+}
+
+func toResultCommitmentResponse(
+	request *api.GetResultCommitmentRequest,
+	result *types.ElectionResult,
+	name []byte,
+) (*api.ResultCommitmentResponse, error) {
+	commitment, err := result.Commitment()
+	if err != nil {
+		return nil, err
+	}
+	t, err := ptypes.TimestampProto(result.MintTime())
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ResultCommitmentResponse{
+		Height:           request.Height,
+		Timestamp:        t,
+		TotalVotes:       result.TotalVotes().Text(10),
+		TotalVotedStakes: result.TotalVotedStakes().Text(10),
+		DelegatesRoot:    hex.EncodeToString(commitment.DelegatesRoot[:]),
+		VotesRoot:        hex.EncodeToString(commitment.VotesRoot[:]),
+		ResultHash:       hex.EncodeToString(commitment.Hash[:]),
+		BucketProofs:     []*api.MerkleProof{},
+	}
+	if len(name) != 0 {
+		delegate := result.DelegateByName(name)
+		if delegate == nil {
+			return nil, errors.Errorf("cannot find delegate %s", request.Name)
+		}
+		proof, err := commitment.DelegateProof(delegate)
+		if err != nil {
+			return nil, err
+		}
+		data, err := types.CandidateLeafData(delegate)
+		if err != nil {
+			return nil, err
+		}
+		response.CandidateProof = toMerkleProof(proof, data)
+	}
+	if request.Bucket != "" {
+		bucketHash, err := hex.DecodeString(strings.TrimPrefix(request.Bucket, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		for _, vote := range result.Votes() {
+			h, err := vote.Hash()
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(h[:], bucketHash) {
+				continue
+			}
+			proof, err := commitment.VoteProof(vote)
+			if err != nil {
+				return nil, err
+			}
+			data, err := types.VoteLeafData(vote)
+			if err != nil {
+				return nil, err
+			}
+			response.BucketProofs = append(response.BucketProofs, toMerkleProof(proof, data))
+		}
+		if len(response.BucketProofs) == 0 {
+			return nil, errors.Errorf("cannot find bucket %s", request.Bucket)
+		}
+	}
+	return response, nil
+}
+
+// GetResultByEpoch returns the candidates of an IoTeX epoch, voted by the buckets on both gravity chain and IoTeX. It is
+// only supported by the committee of gravity chain, where the native polls are stored.
+func (s *server) GetResultByEpoch(ctx context.Context, request *api.GetResultByEpochRequest) (*api.EpochResultResponse, error) {
+	c, ok := s.electionCommittee.(committee.Committee)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	epochNum, err := strconv.ParseUint(request.Epoch, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.ResultByEpoch(epochNum)
+	if err != nil {
+		return nil, err
+	}
+	height, err := c.HeightByTime(result.MintTime())
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := toCandidateResponse(result, request.Offset, request.Limit)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ptypes.TimestampProto(result.MintTime())
+	if err != nil {
+		return nil, err
+	}
+	return &api.EpochResultResponse{
+		Epoch:            request.Epoch,
+		Height:           strconv.FormatUint(height, 10),
+		Timestamp:        t,
+		TotalVotes:       result.TotalVotes().Text(10),
+		TotalVotedStakes: result.TotalVotedStakes().Text(10),
+		Candidates:       candidates.Candidates,
+	}, nil
+}
+
+func toMerkleProof(proof *types.MerkleProof, data []byte) *api.MerkleProof {
+	siblings := make([]string, 0, len(proof.Siblings))
+	for _, sibling := range proof.Siblings {
+		siblings = append(siblings, hex.EncodeToString(sibling[:]))
+	}
+	return &api.MerkleProof{
+		Index:       uint32(proof.Index),
+		NumOfLeaves: uint32(proof.NumOfLeaves),
+		Leaf:        hex.EncodeToString(proof.Leaf[:]),
+		LeafData:    data,
+		Siblings:    siblings,
+	}
+}
+
+func toResultNotification(height uint64, result *types.ElectionResult, topN uint32) (*api.ResultNotification, error) {
+	t, err := ptypes.TimestampProto(result.MintTime())
+	if err != nil {
+		return nil, err
+	}
+	delegates := result.Delegates()
+	if topN != 0 && int(topN) < len(delegates) {
+		delegates = delegates[:topN]
+	}
+	notification := &api.ResultNotification{
+		Height:    strconv.FormatUint(height, 10),
+		Timestamp: t,
+		Delegates: make([]*api.Candidate, 0, len(delegates)),
+	}
+	for _, delegate := range delegates {
+		notification.Delegates = append(notification.Delegates, toCandidate(delegate))
+	}
+	return notification, nil
+}
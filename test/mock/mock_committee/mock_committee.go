@@ -139,6 +139,20 @@ func (mr *MockCommitteeMockRecorder) Status() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockCommittee)(nil).Status))
 }
 
+// Health mocks base method
+func (m *MockCommittee) Health() *committee.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(*committee.Health)
+	return ret0
+}
+
+// Health indicates an expected call of Health
+func (mr *MockCommitteeMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockCommittee)(nil).Health))
+}
+
 // PutNativePollByEpoch mocks base method
 func (m *MockCommittee) PutNativePollByEpoch(arg0 uint64, arg1 time.Time, arg2 []*types.Bucket) error {
 	m.ctrl.T.Helper()
@@ -167,3 +181,77 @@ func (mr *MockCommitteeMockRecorder) NativeBucketsByEpoch(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NativeBucketsByEpoch", reflect.TypeOf((*MockCommittee)(nil).NativeBucketsByEpoch), arg0)
 }
+
+// ResultByEpoch mocks base method
+func (m *MockCommittee) ResultByEpoch(arg0 uint64) (*types.ElectionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResultByEpoch", arg0)
+	ret0, _ := ret[0].(*types.ElectionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResultByEpoch indicates an expected call of ResultByEpoch
+func (mr *MockCommitteeMockRecorder) ResultByEpoch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResultByEpoch", reflect.TypeOf((*MockCommittee)(nil).ResultByEpoch), arg0)
+}
+
+// ScoreOverrides mocks base method
+func (m *MockCommittee) ScoreOverrides() []committee.ScoreOverride {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreOverrides")
+	ret0, _ := ret[0].([]committee.ScoreOverride)
+	return ret0
+}
+
+// ScoreOverrides indicates an expected call of ScoreOverrides
+func (mr *MockCommitteeMockRecorder) ScoreOverrides() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreOverrides", reflect.TypeOf((*MockCommittee)(nil).ScoreOverrides))
+}
+
+// VoterHistory mocks base method
+func (m *MockCommittee) VoterHistory(arg0 []byte, arg1, arg2 uint64) ([]*committee.VoterPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoterHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*committee.VoterPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoterHistory indicates an expected call of VoterHistory
+func (mr *MockCommitteeMockRecorder) VoterHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoterHistory", reflect.TypeOf((*MockCommittee)(nil).VoterHistory), arg0, arg1, arg2)
+}
+
+// CandidateHistory mocks base method
+func (m *MockCommittee) CandidateHistory(arg0 []byte, arg1, arg2, arg3 uint64) ([]*committee.CandidatePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CandidateHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*committee.CandidatePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CandidateHistory indicates an expected call of CandidateHistory
+func (mr *MockCommitteeMockRecorder) CandidateHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidateHistory", reflect.TypeOf((*MockCommittee)(nil).CandidateHistory), arg0, arg1, arg2, arg3)
+}
+
+// SubscribeHeights mocks base method
+func (m *MockCommittee) SubscribeHeights() (<-chan uint64, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeHeights")
+	ret0, _ := ret[0].(<-chan uint64)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// SubscribeHeights indicates an expected call of SubscribeHeights
+func (mr *MockCommitteeMockRecorder) SubscribeHeights() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeHeights", reflect.TypeOf((*MockCommittee)(nil).SubscribeHeights))
+}
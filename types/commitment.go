@@ -0,0 +1,254 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/go-pkgs/hash"
+)
+
+// The prefixes separate the domains of the hashes, such that a leaf cannot be presented as a node and vice versa
+const (
+	merkleLeafPrefix   byte = 0x00
+	merkleNodePrefix   byte = 0x01
+	resultHashPrefix   byte = 0x02
+	maxMerkleTreeDepth      = 64
+)
+
+// ErrNotInCommitment indicates that the candidate or vote is not in the result
+var ErrNotInCommitment = errors.New("not in the commitment")
+
+type (
+	// MerkleProof defines the inclusion proof of a leaf in a merkle tree. Siblings are ordered from the bottom up, and a
+	// level without sibling, i.e., the last node of a level with odd nodes, is skipped.
+	MerkleProof struct {
+		Leaf        hash.Hash256
+		Index       int
+		NumOfLeaves int
+		Siblings    []hash.Hash256
+	}
+
+	// ResultCommitment defines the commitment of an election result. The delegates are committed in rank order, and the
+	// votes are committed in the order of their leaf hashes.
+	ResultCommitment struct {
+		Hash           hash.Hash256
+		DelegatesRoot  hash.Hash256
+		VotesRoot      hash.Hash256
+		delegateLeaves []hash.Hash256
+		voteLeaves     []hash.Hash256
+	}
+)
+
+// MerkleLeaf returns the leaf hash of data
+func MerkleLeaf(data []byte) hash.Hash256 {
+	return hash.Hash256b(append([]byte{merkleLeafPrefix}, data...))
+}
+
+// CandidateLeafData returns the leaf data of a candidate, which is the serialized election.Candidate proto
+func CandidateLeafData(c *Candidate) ([]byte, error) {
+	cPb, err := c.ToProtoMsg()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(cPb)
+}
+
+// VoteLeafData returns the leaf data of a vote, which is the serialized election.Vote proto
+func VoteLeafData(v *Vote) ([]byte, error) {
+	return v.Serialize()
+}
+
+// MerkleRoot returns the root of a merkle tree with the given leaves, and zero hash for an empty tree
+func MerkleRoot(leaves []hash.Hash256) hash.Hash256 {
+	if len(leaves) == 0 {
+		return hash.ZeroHash256
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = merkleLevel(level)
+	}
+	return level[0]
+}
+
+// NewMerkleProof returns the inclusion proof of the index-th leaf
+func NewMerkleProof(leaves []hash.Hash256, index int) (*MerkleProof, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, errors.Errorf("index %d out of range [0, %d)", index, len(leaves))
+	}
+	proof := &MerkleProof{
+		Leaf:        leaves[index],
+		Index:       index,
+		NumOfLeaves: len(leaves),
+		Siblings:    []hash.Hash256{},
+	}
+	level := leaves
+	for i := index; len(level) > 1; i /= 2 {
+		if sibling := i ^ 1; sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		level = merkleLevel(level)
+	}
+	return proof, nil
+}
+
+// Verify returns true if the proof leads the leaf to the root
+func (p *MerkleProof) Verify(root hash.Hash256) bool {
+	if p == nil || p.Index < 0 || p.Index >= p.NumOfLeaves {
+		return false
+	}
+	h := p.Leaf
+	siblings := p.Siblings
+	for i, size, depth := p.Index, p.NumOfLeaves, 0; size > 1; i, size, depth = i/2, (size+1)/2, depth+1 {
+		if depth >= maxMerkleTreeDepth {
+			return false
+		}
+		if i^1 >= size {
+			continue
+		}
+		if len(siblings) == 0 {
+			return false
+		}
+		if i%2 == 0 {
+			h = merkleNode(h, siblings[0])
+		} else {
+			h = merkleNode(siblings[0], h)
+		}
+		siblings = siblings[1:]
+	}
+	return len(siblings) == 0 && h == root
+}
+
+// ResultHash returns the hash of an election result from its mint time, totals, and merkle roots
+func ResultHash(
+	mintTime time.Time,
+	totalVotes *big.Int,
+	totalVotedStakes *big.Int,
+	delegatesRoot hash.Hash256,
+	votesRoot hash.Hash256,
+) hash.Hash256 {
+	var buf bytes.Buffer
+	buf.WriteByte(resultHashPrefix)
+	var ts [12]byte
+	binary.BigEndian.PutUint64(ts[:8], uint64(mintTime.Unix()))
+	binary.BigEndian.PutUint32(ts[8:], uint32(mintTime.Nanosecond()))
+	buf.Write(ts[:])
+	for _, amount := range []*big.Int{totalVotes, totalVotedStakes} {
+		var data []byte
+		if amount != nil {
+			data = amount.Bytes()
+		}
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+		buf.Write(size[:])
+		buf.Write(data)
+	}
+	buf.Write(delegatesRoot[:])
+	buf.Write(votesRoot[:])
+	return hash.Hash256b(buf.Bytes())
+}
+
+// Commitment returns the commitment of the result
+func (r *ElectionResult) Commitment() (*ResultCommitment, error) {
+	c := &ResultCommitment{
+		delegateLeaves: make([]hash.Hash256, len(r.delegates)),
+		voteLeaves:     []hash.Hash256{},
+	}
+	for i, delegate := range r.delegates {
+		data, err := CandidateLeafData(delegate)
+		if err != nil {
+			return nil, err
+		}
+		c.delegateLeaves[i] = MerkleLeaf(data)
+	}
+	for _, vote := range r.Votes() {
+		data, err := VoteLeafData(vote)
+		if err != nil {
+			return nil, err
+		}
+		c.voteLeaves = append(c.voteLeaves, MerkleLeaf(data))
+	}
+	sort.Slice(c.voteLeaves, func(i, j int) bool {
+		return bytes.Compare(c.voteLeaves[i][:], c.voteLeaves[j][:]) < 0
+	})
+	c.DelegatesRoot = MerkleRoot(c.delegateLeaves)
+	c.VotesRoot = MerkleRoot(c.voteLeaves)
+	c.Hash = ResultHash(r.mintTime, r.totalVotes, r.totalVotedStakes, c.DelegatesRoot, c.VotesRoot)
+	return c, nil
+}
+
+// Hash returns the canonical digest of the result, which is independent of the order of votes
+func (r *ElectionResult) Hash() (hash.Hash256, error) {
+	c, err := r.Commitment()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.Hash, nil
+}
+
+// DelegateProof returns the inclusion proof of a delegate against the delegates root
+func (c *ResultCommitment) DelegateProof(delegate *Candidate) (*MerkleProof, error) {
+	data, err := CandidateLeafData(delegate)
+	if err != nil {
+		return nil, err
+	}
+	leaf := MerkleLeaf(data)
+	for i, l := range c.delegateLeaves {
+		if l == leaf {
+			return NewMerkleProof(c.delegateLeaves, i)
+		}
+	}
+	return nil, errors.Wrapf(ErrNotInCommitment, "delegate %x", delegate.Name())
+}
+
+// VoteProof returns the inclusion proof of a vote against the votes root
+func (c *ResultCommitment) VoteProof(vote *Vote) (*MerkleProof, error) {
+	data, err := VoteLeafData(vote)
+	if err != nil {
+		return nil, err
+	}
+	leaf := MerkleLeaf(data)
+	i := sort.Search(len(c.voteLeaves), func(i int) bool {
+		return bytes.Compare(c.voteLeaves[i][:], leaf[:]) >= 0
+	})
+	if i == len(c.voteLeaves) || c.voteLeaves[i] != leaf {
+		return nil, errors.Wrap(ErrNotInCommitment, "vote")
+	}
+	return NewMerkleProof(c.voteLeaves, i)
+}
+
+// merkleLevel returns the parent level, promoting the last node of a level with odd nodes
+func merkleLevel(level []hash.Hash256) []hash.Hash256 {
+	parents := make([]hash.Hash256, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			parents = append(parents, level[i])
+			continue
+		}
+		parents = append(parents, merkleNode(level[i], level[i+1]))
+	}
+	return parents
+}
+
+func merkleNode(left, right hash.Hash256) hash.Hash256 {
+	data := make([]byte, 0, 1+2*len(left))
+	data = append(data, merkleNodePrefix)
+	data = append(data, left[:]...)
+	data = append(data, right[:]...)
+	return hash.Hash256b(data)
+}
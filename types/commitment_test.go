@@ -0,0 +1,119 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/go-pkgs/hash"
+)
+
+func TestMerkleProof(t *testing.T) {
+	require := require.New(t)
+	require.Equal(hash.ZeroHash256, MerkleRoot(nil))
+	for size := 1; size <= 9; size++ {
+		leaves := make([]hash.Hash256, size)
+		for i := range leaves {
+			leaves[i] = MerkleLeaf([]byte{byte(i)})
+		}
+		root := MerkleRoot(leaves)
+		for i := range leaves {
+			proof, err := NewMerkleProof(leaves, i)
+			require.NoError(err)
+			require.True(proof.Verify(root), "size %d index %d", size, i)
+			proof.Leaf = MerkleLeaf([]byte{byte(size + i)})
+			require.False(proof.Verify(root))
+			if size > 1 {
+				proof.Leaf = leaves[i]
+				proof.Index = (i + 1) % size
+				require.False(proof.Verify(root))
+			}
+		}
+		_, err := NewMerkleProof(leaves, size)
+		require.Error(err)
+	}
+}
+
+func TestResultCommitment(t *testing.T) {
+	require := require.New(t)
+	mintTime := time.Unix(1600000000, 0)
+	calculate := func(buckets []*Bucket) *ElectionResult {
+		calculator := NewResultCalculator(
+			mintTime,
+			false,
+			func(*Bucket) bool { return false },
+			func(v *Bucket, _ time.Time) *big.Int { return v.Amount() },
+			func(*Candidate) bool { return false },
+		)
+		require.NoError(calculator.AddRegistrations([]*Registration{
+			NewRegistration([]byte("candidate1"), []byte("address1"), []byte("operator"), []byte("reward"), 1),
+			NewRegistration([]byte("candidate2"), []byte("address2"), []byte("operator"), []byte("reward"), 1),
+		}))
+		require.NoError(calculator.AddBuckets(buckets))
+		result, err := calculator.Calculate()
+		require.NoError(err)
+		return result
+	}
+	buckets := []*Bucket{}
+	for i, candidate := range []string{"candidate1", "candidate2", "candidate1", "candidate2", "candidate1"} {
+		bucket, err := NewBucket(mintTime, time.Hour, big.NewInt(int64(10*(i+1))), []byte{byte(i)}, []byte(candidate), false)
+		require.NoError(err)
+		buckets = append(buckets, bucket)
+	}
+	result := calculate(buckets)
+	reversed := calculate([]*Bucket{buckets[4], buckets[3], buckets[2], buckets[1], buckets[0]})
+	h1, err := result.Hash()
+	require.NoError(err)
+	h2, err := reversed.Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+
+	// the hash survives a serialization round trip
+	data, err := result.Serialize()
+	require.NoError(err)
+	clone := &ElectionResult{}
+	require.NoError(clone.Deserialize(data))
+	h3, err := clone.Hash()
+	require.NoError(err)
+	require.Equal(h1, h3)
+
+	h4, err := calculate(buckets[:4]).Hash()
+	require.NoError(err)
+	require.NotEqual(h1, h4)
+
+	c, err := result.Commitment()
+	require.NoError(err)
+	require.Equal(h1, c.Hash)
+	require.Equal(c.Hash, ResultHash(result.MintTime(), result.TotalVotes(), result.TotalVotedStakes(), c.DelegatesRoot, c.VotesRoot))
+	for _, delegate := range result.Delegates() {
+		proof, err := c.DelegateProof(delegate)
+		require.NoError(err)
+		data, err := CandidateLeafData(delegate)
+		require.NoError(err)
+		require.Equal(MerkleLeaf(data), proof.Leaf)
+		require.True(proof.Verify(c.DelegatesRoot))
+		require.False(proof.Verify(c.VotesRoot))
+	}
+	for _, vote := range result.Votes() {
+		proof, err := c.VoteProof(vote)
+		require.NoError(err)
+		require.True(proof.Verify(c.VotesRoot))
+	}
+	vote, err := NewVote(buckets[0], big.NewInt(1))
+	require.NoError(err)
+	_, err = c.VoteProof(vote)
+	require.Equal(ErrNotInCommitment, errors.Cause(err))
+}
@@ -52,11 +52,11 @@ func (r *ElectionResult) VotesByDelegate(name []byte) []*Vote {
 	return r.votes[hex.EncodeToString(name)]
 }
 
-// Votes returns all votes
+// Votes returns all votes, grouped by delegate in rank order
 func (r *ElectionResult) Votes() []*Vote {
 	votes := []*Vote{}
-	for _, vs := range r.votes {
-		votes = append(votes, vs...)
+	for _, delegate := range r.delegates {
+		votes = append(votes, r.votes[hex.EncodeToString(delegate.Name())]...)
 	}
 	return votes
 }
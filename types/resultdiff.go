@@ -0,0 +1,154 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"sort"
+
+	"github.com/iotexproject/go-pkgs/hash"
+)
+
+type (
+	// CandidateDiff defines the change of a candidate between two results. A rank starts from 1, and 0 stands for
+	// absence in the result.
+	CandidateDiff struct {
+		Name        []byte
+		RankBefore  int
+		RankAfter   int
+		ScoreBefore *big.Int
+		ScoreAfter  *big.Int
+	}
+
+	// VoteDiff defines the change of a vote between two results. A nil vote stands for absence in the result.
+	VoteDiff struct {
+		Hash   hash.Hash256
+		Before *Vote
+		After  *Vote
+	}
+
+	// ResultDiff defines the difference between two election results
+	ResultDiff struct {
+		// EnteredCandidates are the candidates only in the latter result, sorted by rank
+		EnteredCandidates []*CandidateDiff
+		// LeftCandidates are the candidates only in the former result, sorted by rank
+		LeftCandidates []*CandidateDiff
+		// ChangedCandidates are the candidates in both results with a different rank or score, sorted by rank
+		ChangedCandidates []*CandidateDiff
+		// AddedVotes are the votes only in the latter result, sorted by hash
+		AddedVotes []*VoteDiff
+		// RemovedVotes are the votes only in the former result, sorted by hash
+		RemovedVotes []*VoteDiff
+		// ChangedVotes are the votes in both results with a different weighted amount, sorted by hash
+		ChangedVotes []*VoteDiff
+	}
+)
+
+// ScoreDelta returns the score after minus the score before
+func (d *CandidateDiff) ScoreDelta() *big.Int {
+	return new(big.Int).Sub(d.ScoreAfter, d.ScoreBefore)
+}
+
+// DiffResults returns the difference from result a to result b. The votes are keyed by bucket hash, and the duplicate
+// buckets are compared by their number of occurrences.
+func DiffResults(a, b *ElectionResult) (*ResultDiff, error) {
+	diff := &ResultDiff{
+		EnteredCandidates: []*CandidateDiff{},
+		LeftCandidates:    []*CandidateDiff{},
+		ChangedCandidates: []*CandidateDiff{},
+		AddedVotes:        []*VoteDiff{},
+		RemovedVotes:      []*VoteDiff{},
+		ChangedVotes:      []*VoteDiff{},
+	}
+	candidates := map[string]*CandidateDiff{}
+	for i, d := range a.Delegates() {
+		candidates[hex.EncodeToString(d.Name())] = &CandidateDiff{
+			Name:        d.Name(),
+			RankBefore:  i + 1,
+			ScoreBefore: new(big.Int).Set(d.Score()),
+			ScoreAfter:  big.NewInt(0),
+		}
+	}
+	for i, d := range b.Delegates() {
+		key := hex.EncodeToString(d.Name())
+		cd, ok := candidates[key]
+		if !ok {
+			cd = &CandidateDiff{Name: d.Name(), ScoreBefore: big.NewInt(0)}
+			candidates[key] = cd
+		}
+		cd.RankAfter = i + 1
+		cd.ScoreAfter = new(big.Int).Set(d.Score())
+	}
+	for _, cd := range candidates {
+		switch {
+		case cd.RankBefore == 0:
+			diff.EnteredCandidates = append(diff.EnteredCandidates, cd)
+		case cd.RankAfter == 0:
+			diff.LeftCandidates = append(diff.LeftCandidates, cd)
+		case cd.RankBefore != cd.RankAfter || cd.ScoreBefore.Cmp(cd.ScoreAfter) != 0:
+			diff.ChangedCandidates = append(diff.ChangedCandidates, cd)
+		}
+	}
+	sort.Slice(diff.EnteredCandidates, func(i, j int) bool {
+		return diff.EnteredCandidates[i].RankAfter < diff.EnteredCandidates[j].RankAfter
+	})
+	sort.Slice(diff.LeftCandidates, func(i, j int) bool {
+		return diff.LeftCandidates[i].RankBefore < diff.LeftCandidates[j].RankBefore
+	})
+	sort.Slice(diff.ChangedCandidates, func(i, j int) bool {
+		return diff.ChangedCandidates[i].RankAfter < diff.ChangedCandidates[j].RankAfter
+	})
+
+	votesBefore, err := votesByHash(a.Votes())
+	if err != nil {
+		return nil, err
+	}
+	votesAfter, err := votesByHash(b.Votes())
+	if err != nil {
+		return nil, err
+	}
+	for h, before := range votesBefore {
+		after := votesAfter[h]
+		for i := len(after); i < len(before); i++ {
+			diff.RemovedVotes = append(diff.RemovedVotes, &VoteDiff{Hash: h, Before: before[i]})
+		}
+		for i := 0; i < len(before) && i < len(after); i++ {
+			if before[i].WeightedAmount().Cmp(after[i].WeightedAmount()) != 0 {
+				diff.ChangedVotes = append(diff.ChangedVotes, &VoteDiff{Hash: h, Before: before[i], After: after[i]})
+			}
+		}
+	}
+	for h, after := range votesAfter {
+		for i := len(votesBefore[h]); i < len(after); i++ {
+			diff.AddedVotes = append(diff.AddedVotes, &VoteDiff{Hash: h, After: after[i]})
+		}
+	}
+	for _, votes := range [][]*VoteDiff{diff.AddedVotes, diff.RemovedVotes, diff.ChangedVotes} {
+		sort.SliceStable(votes, func(i, j int) bool {
+			return bytes.Compare(votes[i].Hash[:], votes[j].Hash[:]) < 0
+		})
+	}
+	return diff, nil
+}
+
+func votesByHash(votes []*Vote) (map[hash.Hash256][]*Vote, error) {
+	retval := map[hash.Hash256][]*Vote{}
+	for _, vote := range votes {
+		h, err := vote.Hash()
+		if err != nil {
+			return nil, err
+		}
+		retval[h] = append(retval[h], vote)
+	}
+	return retval, nil
+}
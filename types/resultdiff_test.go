@@ -0,0 +1,108 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResults(t *testing.T) {
+	require := require.New(t)
+	mintTime := time.Unix(1600000000, 0)
+	newBucket := func(amount int64, candidate string) *Bucket {
+		bucket, err := NewBucket(mintTime, time.Hour, big.NewInt(amount), []byte("voter"), []byte(candidate), false)
+		require.NoError(err)
+		return bucket
+	}
+	newRegistration := func(name string) *Registration {
+		return NewRegistration([]byte(name), []byte(name), []byte("operator"), []byte("reward"), 1)
+	}
+	calculate := func(regs []*Registration, buckets []*Bucket, multiplier int64) *ElectionResult {
+		calculator := NewResultCalculator(
+			mintTime,
+			false,
+			func(*Bucket) bool { return false },
+			func(v *Bucket, _ time.Time) *big.Int {
+				return new(big.Int).Mul(v.Amount(), big.NewInt(multiplier))
+			},
+			func(*Candidate) bool { return false },
+		)
+		require.NoError(calculator.AddRegistrations(regs))
+		require.NoError(calculator.AddBuckets(buckets))
+		result, err := calculator.Calculate()
+		require.NoError(err)
+		return result
+	}
+	b1 := newBucket(120, "candidate1")
+	b2 := newBucket(50, "candidate2")
+	b3 := newBucket(200, "candidate3")
+	a := calculate(
+		[]*Registration{newRegistration("candidate1"), newRegistration("candidate2")},
+		[]*Bucket{b1, b2, b2},
+		1,
+	)
+	b := calculate(
+		[]*Registration{newRegistration("candidate2"), newRegistration("candidate3")},
+		[]*Bucket{b2, b3},
+		3,
+	)
+	diff, err := DiffResults(a, b)
+	require.NoError(err)
+
+	require.Equal(1, len(diff.EnteredCandidates))
+	require.Equal([]byte("candidate3"), diff.EnteredCandidates[0].Name)
+	require.Equal(0, diff.EnteredCandidates[0].RankBefore)
+	require.Equal(1, diff.EnteredCandidates[0].RankAfter)
+	require.Equal("600", diff.EnteredCandidates[0].ScoreDelta().String())
+	require.Equal(1, len(diff.LeftCandidates))
+	require.Equal([]byte("candidate1"), diff.LeftCandidates[0].Name)
+	require.Equal(1, diff.LeftCandidates[0].RankBefore)
+	require.Equal(0, diff.LeftCandidates[0].RankAfter)
+	require.Equal("-120", diff.LeftCandidates[0].ScoreDelta().String())
+	require.Equal(1, len(diff.ChangedCandidates))
+	require.Equal([]byte("candidate2"), diff.ChangedCandidates[0].Name)
+	require.Equal(2, diff.ChangedCandidates[0].RankBefore)
+	require.Equal(2, diff.ChangedCandidates[0].RankAfter)
+	require.Equal("100", diff.ChangedCandidates[0].ScoreBefore.String())
+	require.Equal("150", diff.ChangedCandidates[0].ScoreAfter.String())
+
+	h1, err := b1.Hash()
+	require.NoError(err)
+	h2, err := b2.Hash()
+	require.NoError(err)
+	h3, err := b3.Hash()
+	require.NoError(err)
+	require.Equal(1, len(diff.AddedVotes))
+	require.Equal(h3, diff.AddedVotes[0].Hash)
+	require.Nil(diff.AddedVotes[0].Before)
+	require.Equal("600", diff.AddedVotes[0].After.WeightedAmount().String())
+	require.Equal(2, len(diff.RemovedVotes))
+	removed := map[[32]byte]int{}
+	for _, vd := range diff.RemovedVotes {
+		require.Nil(vd.After)
+		removed[vd.Hash]++
+	}
+	require.Equal(1, removed[h1])
+	require.Equal(1, removed[h2])
+	require.Equal(1, len(diff.ChangedVotes))
+	require.Equal(h2, diff.ChangedVotes[0].Hash)
+	require.Equal("50", diff.ChangedVotes[0].Before.WeightedAmount().String())
+	require.Equal("150", diff.ChangedVotes[0].After.WeightedAmount().String())
+
+	diff, err = DiffResults(a, a)
+	require.NoError(err)
+	require.Equal(0, len(diff.EnteredCandidates)+len(diff.LeftCandidates)+len(diff.ChangedCandidates))
+	require.Equal(0, len(diff.AddedVotes)+len(diff.RemovedVotes)+len(diff.ChangedVotes))
+}
@@ -11,7 +11,6 @@
 package types
 
 import (
-	"math"
 	"math/big"
 	"time"
 
@@ -81,20 +80,9 @@ func (v *Vote) WeightedAmount() *big.Int {
 	return new(big.Int).Set(v.weighted)
 }
 
-// CalcWeightedVotes calculates the weighted votes based on time
+// CalcWeightedVotes calculates the weighted votes based on time with the gravity chain weighting policy
 func CalcWeightedVotes(v *Bucket, now time.Time) *big.Int {
-	if now.Before(v.StartTime()) {
-		return big.NewInt(0)
-	}
-	remainingTime := v.RemainingTime(now).Seconds()
-	weight := float64(1)
-	if remainingTime > 0 {
-		weight += math.Log(math.Ceil(remainingTime/86400)) / math.Log(1.2) / 100
-	}
-	amount := new(big.Float).SetInt(v.Amount())
-	weightedAmount, _ := amount.Mul(amount, big.NewFloat(weight)).Int(nil)
-
-	return weightedAmount
+	return WeightedVotes(gravityWeightingV1{}, v, now)
 }
 
 // ToProtoMsg converts the vote to protobuf
@@ -0,0 +1,182 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// GravityWeightingV1 is the name of the weighting policy of gravity chain staking
+	GravityWeightingV1 = "gravity/v1"
+	// NativeWeightingV1 is the name of the weighting policy of native staking, as voted by vote sync
+	NativeWeightingV1 = "native/v1"
+)
+
+type (
+	// WeightingPolicy defines how the votes of a bucket are weighted
+	WeightingPolicy interface {
+		// Name returns the versioned name of the policy
+		Name() string
+		// Weight returns the multiplier of staked amount, given the remaining staking duration, whether the bucket
+		// is auto-staked, and whether it is a self-staking bucket
+		Weight(duration time.Duration, autoStake bool, selfStake bool) float64
+	}
+
+	// WeightingPolicyActivation defines the height from which a weighting policy takes effect
+	WeightingPolicyActivation struct {
+		Name   string `yaml:"name"`
+		Height uint64 `yaml:"height"`
+	}
+
+	// WeightingSchedule defines the weighting policies in effect at different heights
+	WeightingSchedule struct {
+		heights  []uint64
+		policies []WeightingPolicy
+	}
+
+	gravityWeightingV1 struct{}
+
+	nativeWeightingV1 struct{}
+)
+
+var (
+	policyMutex       sync.RWMutex
+	weightingPolicies = map[string]WeightingPolicy{
+		GravityWeightingV1: gravityWeightingV1{},
+		NativeWeightingV1:  nativeWeightingV1{},
+	}
+)
+
+// RegisterWeightingPolicy registers a weighting policy, such that it could be selected by name
+func RegisterWeightingPolicy(policy WeightingPolicy) error {
+	policyMutex.Lock()
+	defer policyMutex.Unlock()
+	if _, ok := weightingPolicies[policy.Name()]; ok {
+		return errors.Errorf("weighting policy %s has been registered", policy.Name())
+	}
+	weightingPolicies[policy.Name()] = policy
+	return nil
+}
+
+// WeightingPolicyByName returns the weighting policy of a given name
+func WeightingPolicyByName(name string) (WeightingPolicy, error) {
+	policyMutex.RLock()
+	defer policyMutex.RUnlock()
+	policy, ok := weightingPolicies[name]
+	if !ok {
+		return nil, errors.Errorf("unknown weighting policy %s", name)
+	}
+	return policy, nil
+}
+
+// WeightedAmount returns the amount weighted by policy
+func WeightedAmount(policy WeightingPolicy, amount *big.Int, duration time.Duration, autoStake bool, selfStake bool) *big.Int {
+	weighted := new(big.Float).SetInt(amount)
+	weightedAmount, _ := weighted.Mul(weighted, big.NewFloat(policy.Weight(duration, autoStake, selfStake))).Int(nil)
+
+	return weightedAmount
+}
+
+// WeightedVotes calculates the weighted votes of a gravity chain bucket at a given time
+func WeightedVotes(policy WeightingPolicy, v *Bucket, now time.Time) *big.Int {
+	if now.Before(v.StartTime()) {
+		return big.NewInt(0)
+	}
+	return WeightedAmount(policy, v.Amount(), v.RemainingTime(now), !v.Decay(), false)
+}
+
+// NewWeightingSchedule creates a schedule starting with the default policy, and switching to the activated ones
+// at their heights
+func NewWeightingSchedule(defaultPolicy string, activations []WeightingPolicyActivation) (*WeightingSchedule, error) {
+	policy, err := WeightingPolicyByName(defaultPolicy)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]WeightingPolicyActivation, len(activations))
+	copy(sorted, activations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+	schedule := &WeightingSchedule{
+		heights:  []uint64{0},
+		policies: []WeightingPolicy{policy},
+	}
+	for _, activation := range sorted {
+		policy, err := WeightingPolicyByName(activation.Name)
+		if err != nil {
+			return nil, err
+		}
+		last := len(schedule.heights) - 1
+		if schedule.heights[last] == activation.Height {
+			if last != 0 {
+				return nil, errors.Errorf("duplicate weighting policy activation height %d", activation.Height)
+			}
+			schedule.policies[last] = policy
+			continue
+		}
+		schedule.heights = append(schedule.heights, activation.Height)
+		schedule.policies = append(schedule.policies, policy)
+	}
+	return schedule, nil
+}
+
+// PolicyAt returns the weighting policy in effect at a given height
+func (s *WeightingSchedule) PolicyAt(height uint64) WeightingPolicy {
+	i := sort.Search(len(s.heights), func(i int) bool {
+		return s.heights[i] > height
+	})
+	return s.policies[i-1]
+}
+
+func (gravityWeightingV1) Name() string {
+	return GravityWeightingV1
+}
+
+// Weight returns 1 + log(ceil(remaining days)) / log(1.2) / 100
+func (gravityWeightingV1) Weight(duration time.Duration, _ bool, _ bool) float64 {
+	remainingTime := duration.Seconds()
+	weight := float64(1)
+	if remainingTime > 0 {
+		weight += math.Log(math.Ceil(remainingTime/86400)) / math.Log(1.2) / 100
+	}
+	return weight
+}
+
+func (nativeWeightingV1) Name() string {
+	return NativeWeightingV1
+}
+
+// Weight returns 1 + log(ceil(staked days / 86400) * (1 + autoStake)) / log(1.2) / 100, multiplied by 1.06 for a
+// self-staking bucket auto-staked for at least 91 days. The staked days are scaled by 86400 to keep the weights
+// voted by vote sync, which took the number of days as seconds.
+func (nativeWeightingV1) Weight(duration time.Duration, autoStake bool, selfStake bool) float64 {
+	days := math.Ceil(duration.Hours() / 24)
+	weight := float64(1)
+	var m float64
+	if autoStake {
+		m = 1
+	}
+	if days > 0 {
+		weight += math.Log(math.Ceil(days/86400)*(1+m)) / math.Log(1.2) / 100
+	}
+	if selfStake && autoStake && days >= 91 {
+		// self-stake extra bonus requires enable auto-stake for at least 3 months
+		weight *= 1.06
+	}
+	return weight
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2019 IoTeX
+// This program is free software: you can redistribute it and/or modify it under the terms of the
+// GNU General Public License as published by the Free Software Foundation, either version 3 of
+// the License, or (at your option) any later version.
+// This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+// without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See
+// the GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License along with this program. If
+// not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type flatWeighting struct{}
+
+func (flatWeighting) Name() string {
+	return "flat/v1"
+}
+
+func (flatWeighting) Weight(time.Duration, bool, bool) float64 {
+	return 1
+}
+
+func TestWeightingSchedule(t *testing.T) {
+	require := require.New(t)
+	_, err := NewWeightingSchedule("unknown/v1", nil)
+	require.Error(err)
+	if _, err := WeightingPolicyByName("flat/v1"); err != nil {
+		require.NoError(RegisterWeightingPolicy(flatWeighting{}))
+	}
+	require.Error(RegisterWeightingPolicy(flatWeighting{}))
+
+	schedule, err := NewWeightingSchedule(GravityWeightingV1, []WeightingPolicyActivation{
+		{Name: "flat/v1", Height: 200},
+		{Name: NativeWeightingV1, Height: 100},
+	})
+	require.NoError(err)
+	require.Equal(GravityWeightingV1, schedule.PolicyAt(0).Name())
+	require.Equal(GravityWeightingV1, schedule.PolicyAt(99).Name())
+	require.Equal(NativeWeightingV1, schedule.PolicyAt(100).Name())
+	require.Equal(NativeWeightingV1, schedule.PolicyAt(199).Name())
+	require.Equal("flat/v1", schedule.PolicyAt(200).Name())
+	require.Equal("flat/v1", schedule.PolicyAt(1000000).Name())
+
+	schedule, err = NewWeightingSchedule(GravityWeightingV1, []WeightingPolicyActivation{{Name: NativeWeightingV1}})
+	require.NoError(err)
+	require.Equal(NativeWeightingV1, schedule.PolicyAt(0).Name())
+
+	_, err = NewWeightingSchedule(GravityWeightingV1, []WeightingPolicyActivation{
+		{Name: NativeWeightingV1, Height: 100},
+		{Name: "flat/v1", Height: 100},
+	})
+	require.Error(err)
+}
+
+func TestWeightedVotes(t *testing.T) {
+	require := require.New(t)
+	startTime := time.Now()
+	bucket, err := NewBucket(startTime, 14*24*time.Hour, big.NewInt(3000000), []byte{}, []byte{}, false)
+	require.NoError(err)
+	policy, err := WeightingPolicyByName(GravityWeightingV1)
+	require.NoError(err)
+	require.Equal(0, WeightedVotes(policy, bucket, startTime.Add(time.Hour)).Cmp(big.NewInt(3434242)))
+	require.Equal(0, CalcWeightedVotes(bucket, startTime.Add(time.Hour)).Cmp(big.NewInt(3434242)))
+	require.Equal(0, WeightedVotes(flatWeighting{}, bucket, startTime.Add(time.Hour)).Cmp(big.NewInt(3000000)))
+}
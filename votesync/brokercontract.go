@@ -13,9 +13,15 @@ import (
 	"github.com/iotexproject/iotex-election/util"
 )
 
+const (
+	brokerResetGasLimit  = 5000000
+	brokerSettleGasLimit = 5000000
+)
+
 type brokerContract struct {
 	contract  iotex.Contract
 	batchSize uint64
+	estimator *gasEstimator
 }
 
 func NewBrokerContract(cli iotex.AuthedClient, addr address.Address, batchSize uint8) (*brokerContract, error) {
@@ -23,11 +29,15 @@ func NewBrokerContract(cli iotex.AuthedClient, addr address.Address, batchSize u
 	if err != nil {
 		return nil, err
 	}
-	return &brokerContract{contract: cli.Contract(addr, brokerABI), batchSize: uint64(batchSize)}, nil
+	return &brokerContract{
+		contract:  cli.Contract(addr, brokerABI),
+		batchSize: uint64(batchSize),
+		estimator: newGasEstimator(cli, addr, brokerABI),
+	}, nil
 }
 
 func (bc *brokerContract) Reset() error {
-	_, err := bc.contract.Execute("reset").SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(5000000).Call(context.Background())
+	_, err := bc.contract.Execute("reset").SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(brokerResetGasLimit).Call(context.Background())
 	return err
 }
 
@@ -50,6 +60,6 @@ func (bc *brokerContract) NextBidToSettle() (uint64, error) {
 func (bc *brokerContract) Settle() error {
 	_, err := bc.contract.Execute(
 		"settle", big.NewInt(0).SetUint64(bc.batchSize)).
-		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(5000000).Call(context.Background())
+		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(brokerSettleGasLimit).Call(context.Background())
 	return err
 }
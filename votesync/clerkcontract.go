@@ -12,8 +12,11 @@ import (
 	"github.com/iotexproject/iotex-election/contract"
 )
 
+const clerkClaimGasLimit = 5000000
+
 type clerkContract struct {
-	contract iotex.Contract
+	contract  iotex.Contract
+	estimator *gasEstimator
 }
 
 func NewClerkContract(cli iotex.AuthedClient, addr address.Address) (*clerkContract, error) {
@@ -21,10 +24,10 @@ func NewClerkContract(cli iotex.AuthedClient, addr address.Address) (*clerkContr
 	if err != nil {
 		return nil, err
 	}
-	return &clerkContract{contract: cli.Contract(addr, clerkABI)}, nil
+	return &clerkContract{contract: cli.Contract(addr, clerkABI), estimator: newGasEstimator(cli, addr, clerkABI)}, nil
 }
 
 func (cc *clerkContract) Claim() error {
-	_, err := cc.contract.Execute("claim").SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(5000000).Call(context.Background())
+	_, err := cc.contract.Execute("claim").SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(clerkClaimGasLimit).Call(context.Background())
 	return err
 }
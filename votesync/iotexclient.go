@@ -2,13 +2,26 @@ package votesync
 
 import (
 	"context"
+	"encoding/hex"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	receiptPollInterval = 5 * time.Second
+	receiptPollAttempts = 24
+)
+
+// errActionFailed is returned if an action lands with a failed receipt
+var errActionFailed = errors.New("action failed")
+
 type iotexClient struct {
 	client iotexapi.APIServiceClient
 }
@@ -51,3 +64,28 @@ func (ic *iotexClient) Tip() (uint64, error) {
 
 	return response.ChainMeta.Height, nil
 }
+
+// WaitForReceipt waits until the action of h lands, and returns an error if it fails or doesn't land in time
+func (ic *iotexClient) WaitForReceipt(ctx context.Context, h hash.Hash256) error {
+	for i := 0; i < receiptPollAttempts; i++ {
+		response, err := ic.client.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
+			ActionHash: hex.EncodeToString(h[:]),
+		})
+		switch status.Code(errors.Cause(err)) {
+		case codes.OK:
+			if s := response.GetReceiptInfo().GetReceipt().GetStatus(); s != uint64(iotextypes.ReceiptStatus_Success) {
+				return errors.Wrapf(errActionFailed, "action %x failed with status %d", h, s)
+			}
+			return nil
+		case codes.NotFound:
+		default:
+			return errors.Wrapf(err, "failed to get receipt of action %x", h)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(receiptPollInterval):
+		}
+	}
+	return errors.Errorf("action %x didn't land in time", h)
+}
@@ -0,0 +1,236 @@
+package votesync
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Plan defines the transactions VoteSync would submit at a tip, which is written in dry-run mode
+type Plan struct {
+	Tip        uint64
+	BlockTime  time.Time
+	AgentMode  bool
+	Sync       bool
+	PrevHeight uint64
+	CurrHeight uint64
+	// Updates are the voting powers to set in the vps contract, sorted by voter
+	Updates      []*WeightedVote
+	Transactions []*PlannedTx
+}
+
+// PlannedTx defines a transaction in a plan
+type PlannedTx struct {
+	Contract     string
+	Method       string
+	Note         string
+	GasLimit     uint64
+	EstimatedGas uint64
+	EstimateErr  error
+}
+
+// Batches returns the number of updateVotingPowers transactions
+func (p *Plan) Batches() int {
+	n := 0
+	for _, tx := range p.Transactions {
+		if tx.Method == "updateVotingPowers" {
+			n++
+		}
+	}
+	return n
+}
+
+// EstimatedGas returns the sum of the gas estimated, and the number of transactions failed to estimate
+func (p *Plan) EstimatedGas() (uint64, int) {
+	gas := uint64(0)
+	failed := 0
+	for _, tx := range p.Transactions {
+		if tx.EstimateErr != nil {
+			failed++
+			continue
+		}
+		gas += tx.EstimatedGas
+	}
+	return gas, failed
+}
+
+// String returns the plan in a human-readable form
+func (p *Plan) String() string {
+	var sb strings.Builder
+	mode := "delta"
+	if p.AgentMode {
+		mode = "agent"
+	}
+	fmt.Fprintf(&sb, "VoteSync plan at tip %d (%s), %s mode\n", p.Tip, p.BlockTime.UTC().Format(time.RFC3339), mode)
+	if p.Sync {
+		fmt.Fprintf(&sb, "cycle: view %d -> %d\n", p.PrevHeight, p.CurrHeight)
+		fmt.Fprintf(&sb, "voting power updates: %d\n", len(p.Updates))
+		for _, vote := range p.Updates {
+			fmt.Fprintf(&sb, "  %s %s\n", vote.Voter, vote.Votes)
+		}
+	} else {
+		fmt.Fprintf(&sb, "cycle: not due, last view %d\n", p.PrevHeight)
+	}
+	fmt.Fprintf(&sb, "transactions: %d, including %d updateVotingPowers batches\n", len(p.Transactions), p.Batches())
+	for i, tx := range p.Transactions {
+		estimated := fmt.Sprintf("%d", tx.EstimatedGas)
+		if tx.EstimateErr != nil {
+			estimated = fmt.Sprintf("failed (%v)", tx.EstimateErr)
+		}
+		fmt.Fprintf(&sb, "  %d. %s.%s", i+1, tx.Contract, tx.Method)
+		if tx.Note != "" {
+			fmt.Fprintf(&sb, " [%s]", tx.Note)
+		}
+		fmt.Fprintf(&sb, " gas limit %d, estimated %s\n", tx.GasLimit, estimated)
+	}
+	gas, failed := p.EstimatedGas()
+	fmt.Fprintf(&sb, "estimated gas: %d", gas)
+	if failed != 0 {
+		fmt.Fprintf(&sb, ", %d transactions failed to estimate", failed)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// Plan computes the transactions VoteSync would submit at the current tip, without submitting any. The gas is
+// estimated against the current state, before any of the transactions lands.
+func (vc *VoteSync) Plan(ctx context.Context) (*Plan, error) {
+	tip, err := vc.client.Tip()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get iotex tip")
+	}
+	blockTime, err := vc.client.BlockTime(tip)
+	if err != nil {
+		return nil, err
+	}
+	p := &Plan{
+		Tip:        tip,
+		BlockTime:  blockTime,
+		AgentMode:  vc.agentContract != nil,
+		PrevHeight: vc.lastViewHeight,
+	}
+	updateHeight := vc.lastUpdateHeight
+	if blockTime.After(vc.lastUpdateTimestamp.Add(vc.timeInternal)) {
+		p.Sync = true
+		p.CurrHeight = tip
+		update, err := vc.votesUpdate(ctx, vc.lastViewHeight, tip)
+		if err != nil {
+			return nil, err
+		}
+		if !update.skip {
+			for i, voter := range update.voters {
+				addr, err := address.FromBytes(voter.Bytes())
+				if err != nil {
+					return nil, err
+				}
+				p.Updates = append(p.Updates, &WeightedVote{Voter: addr.String(), Votes: update.weights[i]})
+			}
+			as, ws := vc.vpsContract.batches(update.voters, update.weights)
+			for i := range as {
+				p.addTx(
+					vc.vpsContract.estimator, "vps", "updateVotingPowers", updateVotingPowersGasLimit,
+					fmt.Sprintf("batch %d/%d, %d voters", i+1, len(as), len(as[i])),
+					as[i], ws[i],
+				)
+			}
+		}
+		viewID := new(big.Int).SetUint64(tip + _viewIDOffsite)
+		p.addTx(vc.vpsContract.estimator, "vps", "rotate", rotateGasLimit, fmt.Sprintf("view %s", viewID), viewID)
+		updateHeight = tip
+	}
+	if updateHeight > vc.lastBrokerUpdateHeight {
+		p.addTx(
+			vc.brokerContract.estimator, "broker", "settle", brokerSettleGasLimit,
+			"repeated until nextBidToSettle stops advancing",
+			new(big.Int).SetUint64(vc.brokerContract.batchSize),
+		)
+		p.addTx(vc.brokerContract.estimator, "broker", "reset", brokerResetGasLimit, "")
+	}
+	if updateHeight > vc.lastClerkUpdateHeight {
+		p.addTx(vc.clerkContract.estimator, "clerk", "claim", clerkClaimGasLimit, "")
+	}
+
+	return p, nil
+}
+
+func (p *Plan) addTx(
+	estimator *gasEstimator,
+	contractName string,
+	method string,
+	gasLimit uint64,
+	note string,
+	args ...interface{},
+) {
+	tx := &PlannedTx{Contract: contractName, Method: method, Note: note, GasLimit: gasLimit}
+	tx.EstimatedGas, tx.EstimateErr = estimator.estimate(method, args...)
+	p.Transactions = append(p.Transactions, tx)
+}
+
+// writePlan writes the plan at the current tip to the plan file, or logs it if no file is configured
+func (vc *VoteSync) writePlan(ctx context.Context) error {
+	p, err := vc.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	if vc.planFile == "" {
+		zap.L().Info("VoteSync plan.", zap.String("plan", p.String()))
+		return nil
+	}
+	if err := os.WriteFile(vc.planFile, []byte(p.String()), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write plan to %s", vc.planFile)
+	}
+	zap.L().Info("Wrote VoteSync plan.", zap.String("file", vc.planFile), zap.Uint64("tip", p.Tip))
+	return nil
+}
+
+// gasEstimator estimates the gas of the executions of a contract sent by the operator
+type gasEstimator struct {
+	api      iotexapi.APIServiceClient
+	caller   address.Address
+	contract address.Address
+	abi      abi.ABI
+}
+
+func newGasEstimator(cli iotex.AuthedClient, contract address.Address, contractABI abi.ABI) *gasEstimator {
+	return &gasEstimator{
+		api:      cli.API(),
+		caller:   cli.Account().Address(),
+		contract: contract,
+		abi:      contractABI,
+	}
+}
+
+func (ge *gasEstimator) estimate(method string, args ...interface{}) (uint64, error) {
+	if ge == nil {
+		return 0, errors.New("no gas estimator")
+	}
+	data, err := ge.abi.Pack(method, args...)
+	if err != nil {
+		return 0, err
+	}
+	response, err := ge.api.EstimateActionGasConsumption(context.Background(), &iotexapi.EstimateActionGasConsumptionRequest{
+		Action: &iotexapi.EstimateActionGasConsumptionRequest_Execution{
+			Execution: &iotextypes.Execution{
+				Amount:   "0",
+				Contract: ge.contract.String(),
+				Data:     data,
+			},
+		},
+		CallerAddress: ge.caller.String(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return response.Gas, nil
+}
@@ -0,0 +1,71 @@
+package votesync
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVotingPowerBatches(t *testing.T) {
+	require := require.New(t)
+	vps := &rwvps{batchSize: 10}
+	addrs := []common.Address{}
+	weights := []*big.Int{}
+	for i := 0; i < 5; i++ {
+		addrs = append(addrs, common.BytesToAddress([]byte{byte(i)}))
+		weights = append(weights, big.NewInt(int64(i)))
+	}
+	as, ws := vps.batches(addrs, weights)
+	require.Equal(3, len(as))
+	require.Equal(addrs[:1], as[0])
+	require.Equal(addrs[1:3], as[1])
+	require.Equal(addrs[3:], as[2])
+	require.Equal(weights[3:], ws[2])
+
+	as, ws = vps.batches(nil, nil)
+	require.Equal(1, len(as))
+	require.Equal(0, len(as[0]))
+	require.Equal(1, len(ws))
+}
+
+func TestSortVotesUpdate(t *testing.T) {
+	require := require.New(t)
+	update := &votesUpdate{
+		voters:  []common.Address{common.BytesToAddress([]byte{2}), common.BytesToAddress([]byte{1})},
+		weights: []*big.Int{big.NewInt(2), big.NewInt(1)},
+	}
+	sortVotesUpdate(update)
+	require.Equal(common.BytesToAddress([]byte{1}), update.voters[0])
+	require.Equal(big.NewInt(1), update.weights[0])
+}
+
+func TestPlanString(t *testing.T) {
+	require := require.New(t)
+	p := &Plan{
+		Tip:        200,
+		BlockTime:  time.Unix(1600000000, 0),
+		Sync:       true,
+		PrevHeight: 100,
+		CurrHeight: 200,
+		Updates:    []*WeightedVote{{Voter: "io1voter", Votes: big.NewInt(10)}},
+	}
+	p.Transactions = []*PlannedTx{
+		{Contract: "vps", Method: "updateVotingPowers", Note: "batch 1/1, 1 voters", GasLimit: 7000000, EstimatedGas: 50000},
+		{Contract: "vps", Method: "rotate", GasLimit: 4000000, EstimatedGas: 30000},
+	}
+	p.addTx(nil, "clerk", "claim", 5000000, "")
+	require.Equal(1, p.Batches())
+	gas, failed := p.EstimatedGas()
+	require.Equal(uint64(80000), gas)
+	require.Equal(1, failed)
+	s := p.String()
+	require.True(strings.Contains(s, "cycle: view 100 -> 200"))
+	require.True(strings.Contains(s, "io1voter 10"))
+	require.True(strings.Contains(s, "transactions: 3, including 1 updateVotingPowers batches"))
+	require.True(strings.Contains(s, "3. clerk.claim gas limit 5000000, estimated failed (no gas estimator)"))
+	require.True(strings.Contains(s, "estimated gas: 80000, 1 transactions failed to estimate"))
+}
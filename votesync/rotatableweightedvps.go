@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
 	"github.com/iotexproject/iotex-antenna-go/v2/utils/unit"
@@ -15,9 +16,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	rotateGasLimit             = 4000000
+	updateVotingPowersGasLimit = 7000000
+)
+
 type rwvps struct {
 	batchSize int
 	contract  iotex.Contract
+	estimator *gasEstimator
 }
 
 func NewRotatableWeightedVPS(cli iotex.AuthedClient, addr address.Address, batchSize uint8) (*rwvps, error) {
@@ -25,7 +32,11 @@ func NewRotatableWeightedVPS(cli iotex.AuthedClient, addr address.Address, batch
 	if err != nil {
 		return nil, err
 	}
-	return &rwvps{contract: cli.Contract(addr, vpsABI), batchSize: int(batchSize)}, nil
+	return &rwvps{
+		contract:  cli.Contract(addr, vpsABI),
+		batchSize: int(batchSize),
+		estimator: newGasEstimator(cli, addr, vpsABI),
+	}, nil
 }
 
 func (vps *rwvps) ViewID() (*big.Int, error) {
@@ -52,44 +63,46 @@ func (vps *rwvps) InactiveViewID() (*big.Int, error) {
 	return util.ToBigInt(ret[0])
 }
 
-func (vps *rwvps) Rotate(viewID *big.Int) error {
-	_, err := vps.contract.Execute("rotate", viewID).
-		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(4000000).Call(context.Background())
-
-	return err
+func (vps *rwvps) Rotate(viewID *big.Int) (hash.Hash256, error) {
+	return vps.contract.Execute("rotate", viewID).
+		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(rotateGasLimit).Call(context.Background())
 }
 
-func (vps *rwvps) UpdateVotingPowers(addrs []common.Address, weights []*big.Int) error {
-	if len(addrs) != len(weights) {
-		return errors.Errorf("addrs and weights are of different lengths, %d vs %d", len(addrs), len(weights))
-	}
+// batches splits the voting powers into the ones of each updateVotingPowers call. An empty update is still sent as a
+// batch.
+func (vps *rwvps) batches(addrs []common.Address, weights []*big.Int) ([][]common.Address, [][]*big.Int) {
 	if len(addrs) == 0 {
-		return vps.updateVotingPowers(addrs, weights)
+		return [][]common.Address{addrs}, [][]*big.Int{weights}
 	}
 	paginationSize := vps.batchSize / 5
+	batchesOfAddrs := [][]common.Address{}
+	batchesOfWeights := [][]*big.Int{}
 	as := []common.Address{}
 	ws := []*big.Int{}
 	for i := range addrs {
 		as = append(as, addrs[i])
 		ws = append(ws, weights[i])
 		if i%paginationSize == 0 {
-			if err := vps.updateVotingPowers(as, ws); err != nil {
-				return err
-			}
+			batchesOfAddrs = append(batchesOfAddrs, as)
+			batchesOfWeights = append(batchesOfWeights, ws)
 			as = []common.Address{}
 			ws = []*big.Int{}
 		}
 	}
 	if len(as) > 0 {
-		return vps.updateVotingPowers(as, ws)
+		batchesOfAddrs = append(batchesOfAddrs, as)
+		batchesOfWeights = append(batchesOfWeights, ws)
 	}
-	return nil
+	return batchesOfAddrs, batchesOfWeights
 }
 
-func (vps *rwvps) updateVotingPowers(addrs []common.Address, weights []*big.Int) error {
-	_, err := vps.contract.Execute("updateVotingPowers", addrs, weights).
-		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(7000000).Call(context.Background())
-	return err
+// UpdateVotingPowers sends a batch of voting powers
+func (vps *rwvps) UpdateVotingPowers(addrs []common.Address, weights []*big.Int) (hash.Hash256, error) {
+	if len(addrs) != len(weights) {
+		return hash.ZeroHash256, errors.Errorf("addrs and weights are of different lengths, %d vs %d", len(addrs), len(weights))
+	}
+	return vps.contract.Execute("updateVotingPowers", addrs, weights).
+		SetGasPrice(big.NewInt(int64(1 * unit.Qev))).SetGasLimit(updateVotingPowersGasLimit).Call(context.Background())
 }
 
 func (vps *rwvps) TotalPower() (*big.Int, error) {
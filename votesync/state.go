@@ -0,0 +1,191 @@
+package votesync
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+const stateNamespace = "voteSync"
+
+var stateKey = []byte("state")
+
+// syncState is the progress of VoteSync stored across restarts
+type syncState struct {
+	LastViewHeight         uint64        `json:"lastViewHeight"`
+	LastViewTimestamp      time.Time     `json:"lastViewTimestamp"`
+	LastUpdateHeight       uint64        `json:"lastUpdateHeight"`
+	LastUpdateTimestamp    time.Time     `json:"lastUpdateTimestamp"`
+	LastBrokerUpdateHeight uint64        `json:"lastBrokerUpdateHeight"`
+	LastClerkUpdateHeight  uint64        `json:"lastClerkUpdateHeight"`
+	LastNativeEpoch        uint64        `json:"lastNativeEpoch"`
+	TempLastNativeEpoch    uint64        `json:"tempLastNativeEpoch"`
+	Pending                *pendingCycle `json:"pending,omitempty"`
+}
+
+// pendingCycle is a cycle whose transactions are partly submitted. The updates are stored rather than recomputed on
+// resumption, as the voting powers read from the vps contract change with the batches landed.
+type pendingCycle struct {
+	PrevHeight    uint64           `json:"prevHeight"`
+	CurrHeight    uint64           `json:"currHeight"`
+	CurrTimestamp time.Time        `json:"currTimestamp"`
+	Skip          bool             `json:"skip"`
+	Voters        []common.Address `json:"voters"`
+	Weights       []*big.Int       `json:"weights"`
+	// Submitted is the number of updates in the batches landed
+	Submitted int `json:"submitted"`
+	// Updated is true once all the batches landed, including the one of an empty update
+	Updated bool `json:"updated"`
+	// BatchTxHash is the hash of the batch sent but not landed yet, whose receipt is waited for rather than resending
+	// the batch on resumption
+	BatchTxHash string `json:"batchTxHash,omitempty"`
+	// BatchSize is the number of updates in the batch of BatchTxHash
+	BatchSize int `json:"batchSize,omitempty"`
+	// RotateTxHash is the hash of the rotation sent but not landed yet
+	RotateTxHash string `json:"rotateTxHash,omitempty"`
+}
+
+func newPendingCycle(prevHeight, currHeight uint64, currTs time.Time, update *votesUpdate) *pendingCycle {
+	return &pendingCycle{
+		PrevHeight:    prevHeight,
+		CurrHeight:    currHeight,
+		CurrTimestamp: currTs,
+		Skip:          update.skip,
+		Voters:        update.voters,
+		Weights:       update.weights,
+	}
+}
+
+func loadState(store db.KVStore) (*syncState, error) {
+	value, err := store.Get(stateKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, bbolt.ErrBucketNotFound:
+		return nil, nil
+	default:
+		return nil, errors.Wrap(err, "failed to read vote sync state")
+	}
+	state := &syncState{}
+	if err := json.Unmarshal(value, state); err != nil {
+		return nil, errors.Wrap(err, "failed to decode vote sync state")
+	}
+	return state, nil
+}
+
+// checkState checks the stored progress against the view of the vps contract, which is the last update height, or the
+// height of the pending cycle if it is rotated before the progress is stored
+func checkState(state *syncState, viewHeight uint64) error {
+	if state.LastUpdateHeight == viewHeight {
+		return nil
+	}
+	if state.Pending != nil && state.Pending.CurrHeight == viewHeight {
+		return nil
+	}
+	return errors.Errorf(
+		"stored view %d differs from view %d of vps contract",
+		state.LastUpdateHeight,
+		viewHeight,
+	)
+}
+
+func (vc *VoteSync) restoreState(state *syncState) {
+	vc.lastViewHeight = state.LastViewHeight
+	vc.lastViewTimestamp = state.LastViewTimestamp
+	vc.lastUpdateHeight = state.LastUpdateHeight
+	vc.lastUpdateTimestamp = state.LastUpdateTimestamp
+	vc.lastBrokerUpdateHeight = state.LastBrokerUpdateHeight
+	vc.lastClerkUpdateHeight = state.LastClerkUpdateHeight
+	vc.lastNativeEphoch = state.LastNativeEpoch
+	vc.tempLastNativeEphoch = state.TempLastNativeEpoch
+	vc.pending = state.Pending
+}
+
+// saveState stores the progress, which is called on every step of a cycle
+func (vc *VoteSync) saveState() error {
+	value, err := json.Marshal(&syncState{
+		LastViewHeight:         vc.lastViewHeight,
+		LastViewTimestamp:      vc.lastViewTimestamp,
+		LastUpdateHeight:       vc.lastUpdateHeight,
+		LastUpdateTimestamp:    vc.lastUpdateTimestamp,
+		LastBrokerUpdateHeight: vc.lastBrokerUpdateHeight,
+		LastClerkUpdateHeight:  vc.lastClerkUpdateHeight,
+		LastNativeEpoch:        vc.lastNativeEphoch,
+		TempLastNativeEpoch:    vc.tempLastNativeEphoch,
+		Pending:                vc.pending,
+	})
+	if err != nil {
+		return err
+	}
+	if err := vc.stateStore.Put(stateKey, value); err != nil {
+		return errors.Wrap(err, "failed to store vote sync state")
+	}
+	return nil
+}
+
+// waitForTx waits for the receipt of the transaction of txHash
+func (vc *VoteSync) waitForTx(ctx context.Context, txHash string) error {
+	h, err := hash.HexStringToHash256(txHash)
+	if err != nil {
+		return errors.Wrapf(err, "invalid hash of pending transaction %s", txHash)
+	}
+	return vc.client.WaitForReceipt(ctx, h)
+}
+
+// waitForBatch waits for the batch sent, and counts its updates as submitted once it lands. The hash is kept if the
+// batch doesn't land in time, and is cleared if the batch fails, such that it is resent.
+func (vc *VoteSync) waitForBatch(ctx context.Context) error {
+	pending := vc.pending
+	err := vc.waitForTx(ctx, pending.BatchTxHash)
+	switch errors.Cause(err) {
+	case nil:
+		pending.Submitted += pending.BatchSize
+		pending.Updated = pending.Submitted == len(pending.Voters)
+	case errActionFailed:
+	default:
+		return errors.Wrap(err, "update vote error")
+	}
+	pending.BatchTxHash = ""
+	pending.BatchSize = 0
+	if err := vc.saveState(); err != nil {
+		return err
+	}
+	return errors.Wrap(err, "update vote error")
+}
+
+// waitForRotation waits for the rotation sent. Like a batch, the hash is kept if the rotation doesn't land in time.
+func (vc *VoteSync) waitForRotation(ctx context.Context) error {
+	err := vc.waitForTx(ctx, vc.pending.RotateTxHash)
+	if err != nil && errors.Cause(err) != errActionFailed {
+		return errors.Wrap(err, "failed to execute rotate")
+	}
+	vc.pending.RotateTxHash = ""
+	if err := vc.saveState(); err != nil {
+		return err
+	}
+	return errors.Wrap(err, "failed to execute rotate")
+}
+
+// resume finishes the cycle partly submitted before, if any
+func (vc *VoteSync) resume(ctx context.Context) error {
+	if vc.pending == nil {
+		return nil
+	}
+	zap.L().Info("Resume VoteSyncing.",
+		zap.Uint64("lastViewID", vc.pending.PrevHeight),
+		zap.Uint64("nextViewID", vc.pending.CurrHeight),
+		zap.Int("submitted", vc.pending.Submitted),
+		zap.Int("voters", len(vc.pending.Voters)),
+		zap.String("batchTxHash", vc.pending.BatchTxHash),
+		zap.String("rotateTxHash", vc.pending.RotateTxHash),
+	)
+	return vc.sync(ctx, vc.pending.PrevHeight, vc.pending.CurrHeight, vc.pending.CurrTimestamp)
+}
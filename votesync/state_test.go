@@ -0,0 +1,185 @@
+package votesync
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-election/db"
+)
+
+type fakeReceiptClient struct {
+	iotexapi.APIServiceClient
+	statuses map[string]iotextypes.ReceiptStatus
+}
+
+func (c *fakeReceiptClient) GetReceiptByAction(
+	ctx context.Context,
+	in *iotexapi.GetReceiptByActionRequest,
+	opts ...grpc.CallOption,
+) (*iotexapi.GetReceiptByActionResponse, error) {
+	s, ok := c.statuses[in.ActionHash]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &iotexapi.GetReceiptByActionResponse{
+		ReceiptInfo: &iotexapi.ReceiptInfo{Receipt: &iotextypes.Receipt{Status: uint64(s)}},
+	}, nil
+}
+
+func TestSyncState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "votesync.db")
+	store := db.NewKVStoreWithNamespaceWrapper(stateNamespace, db.NewBoltDB(path, 3))
+	require.NoError(store.Start(ctx))
+	state, err := loadState(store)
+	require.NoError(err)
+	require.Nil(state)
+
+	now := time.Unix(1600000000, 0)
+	vc := &VoteSync{
+		stateStore:             store,
+		lastViewHeight:         100,
+		lastViewTimestamp:      now,
+		lastUpdateHeight:       200,
+		lastUpdateTimestamp:    now.Add(time.Hour),
+		lastBrokerUpdateHeight: 200,
+		lastClerkUpdateHeight:  100,
+		tempLastNativeEphoch:   3,
+	}
+	vc.pending = newPendingCycle(200, 300, now.Add(2*time.Hour), &votesUpdate{
+		voters:  []common.Address{common.BytesToAddress([]byte{1}), common.BytesToAddress([]byte{2})},
+		weights: []*big.Int{big.NewInt(0), big.NewInt(10)},
+	})
+	vc.pending.Submitted = 1
+	require.NoError(vc.saveState())
+	require.NoError(store.Stop(ctx))
+
+	// the progress survives a restart
+	store = db.NewKVStoreWithNamespaceWrapper(stateNamespace, db.NewBoltDB(path, 3))
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	state, err = loadState(store)
+	require.NoError(err)
+	restored := &VoteSync{stateStore: store}
+	restored.restoreState(state)
+	require.Equal(uint64(100), restored.lastViewHeight)
+	require.Equal(uint64(200), restored.lastUpdateHeight)
+	require.True(now.Add(time.Hour).Equal(restored.lastUpdateTimestamp))
+	require.Equal(uint64(200), restored.lastBrokerUpdateHeight)
+	require.Equal(uint64(100), restored.lastClerkUpdateHeight)
+	require.Equal(uint64(3), restored.tempLastNativeEphoch)
+	require.NotNil(restored.pending)
+	require.Equal(uint64(300), restored.pending.CurrHeight)
+	require.Equal(vc.pending.Voters, restored.pending.Voters)
+	require.Equal(0, big.NewInt(10).Cmp(restored.pending.Weights[1]))
+	require.False(restored.pending.Updated)
+
+	// only the updates not landed are batched on resumption
+	vps := &rwvps{batchSize: 10}
+	as, _ := vps.batches(restored.pending.Voters[restored.pending.Submitted:], restored.pending.Weights[restored.pending.Submitted:])
+	require.Equal(1, len(as))
+	require.Equal([]common.Address{common.BytesToAddress([]byte{2})}, as[0])
+
+	// the cycle completed clears the pending one
+	restored.pending = nil
+	require.NoError(restored.saveState())
+	state, err = loadState(store)
+	require.NoError(err)
+	require.Nil(state.Pending)
+}
+
+func TestCheckState(t *testing.T) {
+	require := require.New(t)
+	state := &syncState{LastUpdateHeight: 200}
+	require.NoError(checkState(state, 200))
+	require.Error(checkState(state, 300))
+	// the pending cycle is rotated before the progress is stored
+	state.Pending = &pendingCycle{PrevHeight: 200, CurrHeight: 300}
+	require.NoError(checkState(state, 300))
+	require.Error(checkState(state, 400))
+}
+
+func TestWaitForPendingTx(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := db.NewKVStoreWithNamespaceWrapper(stateNamespace, db.NewBoltDB(filepath.Join(t.TempDir(), "votesync.db"), 3))
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	txHash := func(b byte) string {
+		h := hash.Hash256b([]byte{b})
+		return hex.EncodeToString(h[:])
+	}
+	client := &fakeReceiptClient{statuses: map[string]iotextypes.ReceiptStatus{
+		txHash(1): iotextypes.ReceiptStatus_Success,
+		txHash(2): iotextypes.ReceiptStatus_Failure,
+	}}
+	vc := &VoteSync{stateStore: store, client: NewIoTeXClient(client), lastUpdateHeight: 200}
+	vc.pending = newPendingCycle(200, 300, time.Unix(1600000000, 0), &votesUpdate{
+		voters:  []common.Address{common.BytesToAddress([]byte{1}), common.BytesToAddress([]byte{2})},
+		weights: []*big.Int{big.NewInt(0), big.NewInt(10)},
+	})
+	stored := func() *pendingCycle {
+		state, err := loadState(store)
+		require.NoError(err)
+		return state.Pending
+	}
+
+	// the batch not landed in time is still waited for on resumption
+	vc.pending.BatchTxHash = txHash(3)
+	vc.pending.BatchSize = 1
+	require.NoError(vc.saveState())
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Error(vc.waitForBatch(cancelled))
+	require.Equal(txHash(3), stored().BatchTxHash)
+	require.Equal(0, stored().Submitted)
+
+	// the failed batch is resent
+	vc.pending.BatchTxHash = txHash(2)
+	require.Error(vc.waitForBatch(ctx))
+	require.Equal("", stored().BatchTxHash)
+	require.Equal(0, stored().Submitted)
+	require.False(stored().Updated)
+
+	// the batch landed is counted
+	vc.pending.BatchTxHash = txHash(1)
+	vc.pending.BatchSize = 1
+	require.NoError(vc.waitForBatch(ctx))
+	require.Equal("", stored().BatchTxHash)
+	require.Equal(1, stored().Submitted)
+	require.False(stored().Updated)
+	vc.pending.BatchTxHash = txHash(1)
+	vc.pending.BatchSize = 1
+	require.NoError(vc.waitForBatch(ctx))
+	require.Equal(2, stored().Submitted)
+	require.True(stored().Updated)
+
+	vc.pending.RotateTxHash = txHash(3)
+	require.NoError(vc.saveState())
+	require.Error(vc.waitForRotation(cancelled))
+	require.Equal(txHash(3), stored().RotateTxHash)
+	vc.pending.RotateTxHash = txHash(2)
+	require.Error(vc.waitForRotation(ctx))
+	require.Equal("", stored().RotateTxHash)
+	vc.pending.RotateTxHash = txHash(1)
+	require.NoError(vc.waitForRotation(ctx))
+	require.Equal("", stored().RotateTxHash)
+}
@@ -1,11 +1,13 @@
 package votesync
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
-	"math"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +23,9 @@ import (
 	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-election/db"
+	"github.com/iotexproject/iotex-election/types"
 )
 
 const _viewIDOffsite = 10000000
@@ -58,29 +63,41 @@ type VoteSync struct {
 	terminated             bool
 	dardanellesHeight      uint64
 	fairbankHeight         uint64
+	weightingSchedule      *types.WeightingSchedule
+	dryRun                 bool
+	planFile               string
+	stateStore             db.KVStore
+	pending                *pendingCycle
 }
 
 // Config defines the configs for VoteSync
 type Config struct {
-	GravityChainAPIs          []string      `yaml:"gravityChainAPIs"`
-	GravityChainTimeInterval  time.Duration `yaml:"gravityChainTimeInterval"`
-	OperatorPrivateKey        string        `yaml:"operatorPrivateKey"`
-	IoTeXAPI                  string        `yaml:"ioTeXAPI"`
-	IoTeXAPISecure            bool          `yaml:"ioTeXAPISecure"`
-	RegisterContractAddress   string        `yaml:"registerContractAddress"`
-	StakingContractAddress    string        `yaml:"stakingContractAddress"`
-	PaginationSize            uint8         `yaml:"paginationSize"`
-	BrokerPaginationSize      uint8         `yaml:"brokerPaginationSize"`
-	VitaContractAddress       string        `yaml:"vitaContractAddress"`
-	DiscordBotToken           string        `yaml:"discordBotToken"`
-	DiscordChannelID          string        `yaml:"discordChannelID"`
-	DiscordMsg                string        `yaml:"discordMsg"`
-	DiscordReminder           string        `yaml:"discordReminder"`
-	DardanellesHeight         uint64        `yaml:"dardanellesHeight"`
-	FairBankHeight            uint64        `yaml:"fairbankHeight"`
-	NativeCommitteeInitHeight uint64        `yaml:"nativeCommitteeInitHeight"`
-	EnableAgentMode           bool          `yaml:"enableAgentMode"`
-	AgentContractAddress      string        `yaml:"agentContractAddress"`
+	GravityChainAPIs          []string                          `yaml:"gravityChainAPIs"`
+	GravityChainTimeInterval  time.Duration                     `yaml:"gravityChainTimeInterval"`
+	OperatorPrivateKey        string                            `yaml:"operatorPrivateKey"`
+	IoTeXAPI                  string                            `yaml:"ioTeXAPI"`
+	IoTeXAPISecure            bool                              `yaml:"ioTeXAPISecure"`
+	RegisterContractAddress   string                            `yaml:"registerContractAddress"`
+	StakingContractAddress    string                            `yaml:"stakingContractAddress"`
+	PaginationSize            uint8                             `yaml:"paginationSize"`
+	BrokerPaginationSize      uint8                             `yaml:"brokerPaginationSize"`
+	VitaContractAddress       string                            `yaml:"vitaContractAddress"`
+	DiscordBotToken           string                            `yaml:"discordBotToken"`
+	DiscordChannelID          string                            `yaml:"discordChannelID"`
+	DiscordMsg                string                            `yaml:"discordMsg"`
+	DiscordReminder           string                            `yaml:"discordReminder"`
+	DardanellesHeight         uint64                            `yaml:"dardanellesHeight"`
+	FairBankHeight            uint64                            `yaml:"fairbankHeight"`
+	NativeCommitteeInitHeight uint64                            `yaml:"nativeCommitteeInitHeight"`
+	EnableAgentMode           bool                              `yaml:"enableAgentMode"`
+	AgentContractAddress      string                            `yaml:"agentContractAddress"`
+	WeightingPolicies         []types.WeightingPolicyActivation `yaml:"weightingPolicies"`
+	// DryRun writes the plan of each cycle instead of submitting any transaction
+	DryRun bool `yaml:"dryRun"`
+	// PlanFile is the file the plan is written to in dry-run mode, the plan is logged if empty
+	PlanFile string `yaml:"planFile"`
+	// StateDBPath is the bolt db file the progress is stored in, which is required
+	StateDBPath string `yaml:"stateDBPath"`
 }
 
 // WeightedVote defines voter and votes for weighted vote
@@ -99,6 +116,9 @@ func ioToEthAddress(str string) (common.Address, error) {
 
 // NewVoteSync instantiates new VoteSync
 func NewVoteSync(cfg Config) (*VoteSync, error) {
+	if cfg.StateDBPath == "" {
+		return nil, errors.New("state db path of vote sync is required")
+	}
 	opts := []grpc_retry.CallOption{
 		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(100 * time.Second)),
 		grpc_retry.WithMax(3),
@@ -211,6 +231,11 @@ func NewVoteSync(cfg Config) (*VoteSync, error) {
 		return nil, err
 	}
 
+	weightingSchedule, err := types.NewWeightingSchedule(types.NativeWeightingV1, cfg.WeightingPolicies)
+	if err != nil {
+		return nil, err
+	}
+
 	var agentContract *agentContract
 	if cfg.EnableAgentMode {
 		agentContractAddress, err := address.FromString(cfg.AgentContractAddress)
@@ -223,7 +248,16 @@ func NewVoteSync(cfg Config) (*VoteSync, error) {
 		}
 	}
 
-	return &VoteSync{
+	stateStore := db.NewKVStoreWithNamespaceWrapper(stateNamespace, db.NewBoltDB(cfg.StateDBPath, 3))
+	if err := stateStore.Start(context.Background()); err != nil {
+		return nil, errors.Wrap(err, "failed to start vote sync state db")
+	}
+	state, err := loadState(stateStore)
+	if err != nil {
+		return nil, err
+	}
+
+	vc := &VoteSync{
 		client:                 apiClient,
 		agentContract:          agentContract,
 		votingPowers:           &VotingPowers{},
@@ -248,7 +282,19 @@ func NewVoteSync(cfg Config) (*VoteSync, error) {
 		},
 		dardanellesHeight: cfg.DardanellesHeight,
 		fairbankHeight:    cfg.FairBankHeight,
-	}, nil
+		weightingSchedule: weightingSchedule,
+		dryRun:            cfg.DryRun,
+		planFile:          cfg.PlanFile,
+		stateStore:        stateStore,
+	}
+	if state != nil {
+		// the stored progress wins, as the contracts don't record a cycle interrupted
+		if err := checkState(state, vc.lastUpdateHeight); err != nil {
+			return nil, err
+		}
+		vc.restoreState(state)
+	}
+	return vc, nil
 }
 
 // Start starts voteSync
@@ -260,8 +306,15 @@ func (vc *VoteSync) Start(ctx context.Context) {
 		zap.Uint64("lastBrokerUpdateHeight", vc.lastBrokerUpdateHeight),
 		zap.Uint64("lastClerkUpdateHeight", vc.lastClerkUpdateHeight),
 		zap.Uint64("lastViewID", vc.lastViewHeight),
+		zap.Bool("dryRun", vc.dryRun),
+		zap.Bool("pending", vc.pending != nil),
 	)
 	go func() {
+		if !vc.dryRun {
+			if err := vc.resume(ctx); err != nil {
+				zap.L().Error("failed to resume votes sync", zap.Error(err))
+			}
+		}
 		ticker := time.NewTicker(10 * time.Minute)
 		defer ticker.Stop()
 		for {
@@ -269,6 +322,12 @@ func (vc *VoteSync) Start(ctx context.Context) {
 			case <-vc.terminate:
 				return
 			case <-ticker.C:
+				if vc.dryRun {
+					if err := vc.writePlan(ctx); err != nil {
+						zap.L().Error("failed to write plan", zap.Error(err))
+					}
+					continue
+				}
 				tip, err := vc.client.Tip()
 				if err != nil {
 					zap.L().Error("failed to get iotex tip", zap.Error(err))
@@ -279,6 +338,12 @@ func (vc *VoteSync) Start(ctx context.Context) {
 					zap.L().Error("failed to get block time", zap.Error(err))
 					continue
 				}
+				if vc.pending != nil {
+					if err := vc.resume(ctx); err != nil {
+						zap.L().Error("failed to resume votes sync", zap.Error(err))
+						continue
+					}
+				}
 				if blockTime.After(vc.lastUpdateTimestamp.Add(vc.timeInternal)) {
 					if err := vc.sync(ctx, vc.lastViewHeight, tip, blockTime); err != nil {
 						zap.L().Error("failed to sync votes", zap.Error(err))
@@ -319,6 +384,9 @@ func (vc *VoteSync) Stop(ctx context.Context) {
 	}
 	close(vc.terminate)
 	vc.terminated = true
+	if err := vc.stateStore.Stop(ctx); err != nil {
+		zap.L().Error("failed to stop vote sync state db", zap.Error(err))
+	}
 }
 
 func (vc *VoteSync) ProofForAccount(acct address.Address) (*big.Int, *big.Int, []byte, error) {
@@ -376,7 +444,7 @@ func (vc *VoteSync) settle(h uint64) error {
 	}
 	vc.lastBrokerUpdateHeight = h
 	l.Info("Finished broker reset.", zap.Uint64("brokerUpdatedHeight", h))
-	return nil
+	return vc.saveState()
 }
 
 func (vc *VoteSync) claimForClerk() error {
@@ -386,75 +454,183 @@ func (vc *VoteSync) claimForClerk() error {
 	}
 	vc.lastClerkUpdateHeight = vc.lastUpdateHeight
 	zap.L().Info("Finished clerk.", zap.Uint64("cleerkUpdatedHeight", vc.lastUpdateHeight))
-	return nil
+	return vc.saveState()
 }
 
-func (vc *VoteSync) sync(ctx context.Context, prevHeight, currHeight uint64, currTs time.Time) error {
-	zap.L().Info("Start VoteSyncing.", zap.Uint64("lastViewID", prevHeight), zap.Uint64("nextViewID", currHeight))
+// votesUpdate is the voting power updates of the vps contract in a cycle
+type votesUpdate struct {
+	voters  []common.Address
+	weights []*big.Int
+	// skip is true if the vps contract holds the fixed power of the agent already
+	skip bool
+	// totalVotes and votingPowers are served to the agent contract in agent mode
+	totalVotes   *big.Int
+	votingPowers map[common.Address]*big.Int
+}
+
+// votesUpdate computes the voting power updates of the cycle from prevHeight to currHeight, sorted by voter
+func (vc *VoteSync) votesUpdate(ctx context.Context, prevHeight, currHeight uint64) (*votesUpdate, error) {
+	update := &votesUpdate{}
 	if vc.agentContract != nil {
-		buckets, candidates, err := vc.fetcher.FetchBucketsByHeight(ctx, currHeight)
+		totalVotes, votingPowers, err := vc.agentVotingPowers(ctx, currHeight)
 		if err != nil {
-			return err
-		}
-		totalVotes := big.NewInt(0)
-		votingPowers := make(map[common.Address]*big.Int)
-		votes := calWeightedVotes(buckets, candidates)
-		for _, vote := range votes {
-			totalVotes = totalVotes.Add(totalVotes, vote.Votes)
-			addr, err := ioToEthAddress(vote.Voter)
-			if err != nil {
-				return err
-			}
-			votingPowers[addr] = vote.Votes
+			return nil, err
 		}
+		update.totalVotes = totalVotes
+		update.votingPowers = votingPowers
 		totalPower, err := vc.vpsContract.TotalPower()
 		if err != nil {
+			return nil, err
+		}
+		if totalPower.Cmp(_fixedAdhocPower) == 0 {
+			update.skip = true
+			return update, nil
+		}
+		powers, err := vc.vpsContract.VoterPowers()
+		if err != nil {
+			return nil, err
+		}
+		for voter := range powers {
+			update.voters = append(update.voters, voter)
+			update.weights = append(update.weights, big.NewInt(0))
+		}
+		sortVotesUpdate(update)
+		update.voters = append(update.voters, vc.agentContract.Address())
+		update.weights = append(update.weights, _fixedAdhocPower)
+		return update, nil
+	}
+	ret, err := vc.fetchVotesUpdate(ctx, prevHeight, currHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch vote error")
+	}
+	zap.L().Info("Need to sync.", zap.Int("numVoter", len(ret)))
+	for _, vote := range ret {
+		addr, err := ioToEthAddress(vote.Voter)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed convert address:%s", vote.Voter))
+		}
+		update.voters = append(update.voters, addr)
+		update.weights = append(update.weights, vote.Votes)
+	}
+	sortVotesUpdate(update)
+	return update, nil
+}
+
+// agentVotingPowers returns the total votes and the voting powers at currHeight served to the agent contract
+func (vc *VoteSync) agentVotingPowers(ctx context.Context, currHeight uint64) (*big.Int, map[common.Address]*big.Int, error) {
+	buckets, candidates, err := vc.fetcher.FetchBucketsByHeight(ctx, currHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalVotes := big.NewInt(0)
+	votingPowers := make(map[common.Address]*big.Int)
+	votes := calWeightedVotes(vc.weightingSchedule.PolicyAt(currHeight), buckets, candidates)
+	for _, vote := range votes {
+		totalVotes = totalVotes.Add(totalVotes, vote.Votes)
+		addr, err := ioToEthAddress(vote.Voter)
+		if err != nil {
+			return nil, nil, err
+		}
+		votingPowers[addr] = vote.Votes
+	}
+	return totalVotes, votingPowers, nil
+}
+
+func sortVotesUpdate(update *votesUpdate) {
+	sort.Sort(byVoter{update})
+}
+
+type byVoter struct {
+	*votesUpdate
+}
+
+func (bv byVoter) Len() int {
+	return len(bv.voters)
+}
+
+func (bv byVoter) Less(i, j int) bool {
+	return bytes.Compare(bv.voters[i].Bytes(), bv.voters[j].Bytes()) < 0
+}
+
+func (bv byVoter) Swap(i, j int) {
+	bv.voters[i], bv.voters[j] = bv.voters[j], bv.voters[i]
+	bv.weights[i], bv.weights[j] = bv.weights[j], bv.weights[i]
+}
+
+// sync submits the voting power updates of the cycle from prevHeight to currHeight in batches, and then rotates the
+// view. The hash of each transaction is stored once it is sent, and the progress once it lands, such that a cycle
+// interrupted is resumed by waiting for the transaction sent, or from the first batch not landed.
+func (vc *VoteSync) sync(ctx context.Context, prevHeight, currHeight uint64, currTs time.Time) error {
+	zap.L().Info("Start VoteSyncing.", zap.Uint64("lastViewID", prevHeight), zap.Uint64("nextViewID", currHeight))
+	var update *votesUpdate
+	if vc.pending == nil || vc.pending.PrevHeight != prevHeight || vc.pending.CurrHeight != currHeight {
+		var err error
+		if update, err = vc.votesUpdate(ctx, prevHeight, currHeight); err != nil {
 			return err
 		}
-		if totalPower.Cmp(_fixedAdhocPower) != 0 {
-			votes, err := vc.vpsContract.VoterPowers()
+		vc.pending = newPendingCycle(prevHeight, currHeight, currTs, update)
+		if err := vc.saveState(); err != nil {
+			return err
+		}
+	}
+	pending := vc.pending
+	if pending.BatchTxHash != "" {
+		if err := vc.waitForBatch(ctx); err != nil {
+			return err
+		}
+	}
+	if !pending.Skip && !pending.Updated {
+		as, ws := vc.vpsContract.batches(pending.Voters[pending.Submitted:], pending.Weights[pending.Submitted:])
+		for i := range as {
+			h, err := vc.vpsContract.UpdateVotingPowers(as[i], ws[i])
 			if err != nil {
-				return err
+				return errors.Wrap(err, "update vote error")
 			}
-			voters := make([]common.Address, 0, len(votes)+1)
-			weights := make([]*big.Int, 0, len(votes)+1)
-			for voter := range votes {
-				voters = append(voters, voter)
-				weights = append(weights, big.NewInt(0))
+			// the batch sent is waited for rather than resent on resumption
+			pending.BatchTxHash = hex.EncodeToString(h[:])
+			pending.BatchSize = len(as[i])
+			if err := vc.saveState(); err != nil {
+				return err
 			}
-			voters = append(voters, vc.agentContract.Address())
-			weights = append(weights, _fixedAdhocPower)
-			if err := vc.vpsContract.UpdateVotingPowers(voters, weights); err != nil {
+			if err := vc.waitForBatch(ctx); err != nil {
 				return err
 			}
+			zap.L().Info("Updated voting powers.", zap.Int("batch", i+1), zap.Int("batches", len(as)), zap.Int("submitted", pending.Submitted))
 		}
-		vc.votingPowers.Update(nil, totalVotes, votingPowers)
-	} else {
-		ret, err := vc.fetchVotesUpdate(ctx, prevHeight, currHeight)
-		if err != nil {
-			return errors.Wrap(err, "fetch vote error")
-		}
-		zap.L().Info("Need to sync.", zap.Int("numVoter", len(ret)))
-
-		var (
-			addrs   []common.Address
-			weights []*big.Int
-		)
-		for _, vote := range ret {
-			addr, err := ioToEthAddress(vote.Voter)
+	}
+	if vc.agentContract != nil {
+		if update == nil {
+			totalVotes, votingPowers, err := vc.agentVotingPowers(ctx, currHeight)
 			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed convert address:%s", vote.Voter))
+				return err
 			}
-			addrs = append(addrs, addr)
-			weights = append(weights, vote.Votes)
-		}
-		if err := vc.vpsContract.UpdateVotingPowers(addrs, weights); err != nil {
-			return errors.Wrap(err, "update vote error")
+			update = &votesUpdate{totalVotes: totalVotes, votingPowers: votingPowers}
 		}
+		vc.votingPowers.Update(nil, update.totalVotes, update.votingPowers)
 	}
 
-	if err := vc.vpsContract.Rotate(new(big.Int).SetUint64(currHeight + _viewIDOffsite)); err != nil {
-		return errors.Wrap(err, "failed to execute rotate")
+	if pending.RotateTxHash != "" {
+		if err := vc.waitForRotation(ctx); err != nil {
+			return err
+		}
+	}
+	viewID := new(big.Int).SetUint64(currHeight + _viewIDOffsite)
+	rotated, err := vc.vpsContract.ViewID()
+	if err != nil {
+		return err
+	}
+	if rotated.Cmp(viewID) != 0 {
+		h, err := vc.vpsContract.Rotate(viewID)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute rotate")
+		}
+		pending.RotateTxHash = hex.EncodeToString(h[:])
+		if err := vc.saveState(); err != nil {
+			return err
+		}
+		if err := vc.waitForRotation(ctx); err != nil {
+			return err
+		}
 	}
 
 	vc.lastViewHeight = vc.lastUpdateHeight
@@ -462,6 +638,10 @@ func (vc *VoteSync) sync(ctx context.Context, prevHeight, currHeight uint64, cur
 	vc.lastUpdateHeight = currHeight
 	vc.lastUpdateTimestamp = currTs
 	vc.lastNativeEphoch = vc.tempLastNativeEphoch
+	vc.pending = nil
+	if err := vc.saveState(); err != nil {
+		return err
+	}
 	zap.L().Info("Successfully synced votes.", zap.Uint64("lastViewID", vc.lastViewHeight), zap.Uint64("viewID", currHeight))
 	return nil
 }
@@ -473,7 +653,7 @@ func (vc *VoteSync) fetchVotesUpdate(ctx context.Context, prevHeight, currHeight
 		if err != nil {
 			return nil, err
 		}
-		n := calWeightedVotes(currB, currC)
+		n := calWeightedVotes(vc.weightingSchedule.PolicyAt(currHeight), currB, currC)
 		var ret []*WeightedVote
 		for _, nv := range n {
 			ret = append(ret, nv)
@@ -491,8 +671,8 @@ func (vc *VoteSync) fetchVotesUpdate(ctx context.Context, prevHeight, currHeight
 		return nil, err
 	}
 
-	p := calWeightedVotes(prevB, prevC)
-	n := calWeightedVotes(currB, currC)
+	p := calWeightedVotes(vc.weightingSchedule.PolicyAt(prevHeight), prevB, prevC)
+	n := calWeightedVotes(vc.weightingSchedule.PolicyAt(currHeight), currB, currC)
 
 	var ret []*WeightedVote
 	// check for all voters in old view
@@ -521,7 +701,7 @@ func (vc *VoteSync) fetchVotesUpdate(ctx context.Context, prevHeight, currHeight
 	return ret, nil
 }
 
-func calWeightedVotes(bs *iotextypes.VoteBucketList, cs *iotextypes.CandidateListV2) map[string]*WeightedVote {
+func calWeightedVotes(policy types.WeightingPolicy, bs *iotextypes.VoteBucketList, cs *iotextypes.CandidateListV2) map[string]*WeightedVote {
 	n := make(map[string]*WeightedVote)
 	for _, v := range bs.GetBuckets() {
 		var selfStake bool
@@ -532,7 +712,7 @@ func calWeightedVotes(bs *iotextypes.VoteBucketList, cs *iotextypes.CandidateLis
 			}
 		}
 
-		vs := calculateVoteWeight(v, selfStake)
+		vs := calculateVoteWeight(policy, v, selfStake)
 		wv, ok := n[v.GetOwner()]
 		if ok {
 			wv.Votes.Add(wv.Votes, vs)
@@ -546,26 +726,13 @@ func calWeightedVotes(bs *iotextypes.VoteBucketList, cs *iotextypes.CandidateLis
 	return n
 }
 
-func calculateVoteWeight(v *iotextypes.VoteBucket, selfStake bool) *big.Int {
-	durationLg := 1.2
-	autoStake := 1.0
-	selfStakeR := 1.06
-	remainingTime := float64(v.StakedDuration)
-	weight := float64(1)
-	var m float64
-	if v.AutoStake {
-		m = autoStake
-	}
-	if remainingTime > 0 {
-		weight += math.Log(math.Ceil(remainingTime/86400)*(1+m)) / math.Log(durationLg) / 100
-	}
-	if selfStake && v.AutoStake && v.StakedDuration >= 91 {
-		// self-stake extra bonus requires enable auto-stake for at least 3 months
-		weight *= selfStakeR
-	}
-
+func calculateVoteWeight(policy types.WeightingPolicy, v *iotextypes.VoteBucket, selfStake bool) *big.Int {
 	a, _ := new(big.Int).SetString(v.StakedAmount, 10)
-	amount := new(big.Float).SetInt(a)
-	weightedAmount, _ := amount.Mul(amount, big.NewFloat(weight)).Int(nil)
-	return weightedAmount
+	return types.WeightedAmount(
+		policy,
+		a,
+		time.Duration(v.StakedDuration)*24*time.Hour,
+		v.AutoStake,
+		selfStake,
+	)
 }
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-election/types"
 )
 
 var cfg = Config{
@@ -43,3 +46,30 @@ func TestFetchVoteUpdate(t *testing.T) {
 	// TODO: this is due to incomplete staking index db, fix later
 	require.Zero(len(re))
 }
+
+func TestCalculateVoteWeight(t *testing.T) {
+	require := require.New(t)
+	policy, err := types.WeightingPolicyByName(types.NativeWeightingV1)
+	require.NoError(err)
+	for _, c := range []struct {
+		amount    string
+		duration  uint32
+		autoStake bool
+		selfStake bool
+		expected  string
+	}{
+		{"1200000000000000000000000", 0, false, false, "1200000000000000000000000"},
+		{"1200000000000000000000000", 0, true, true, "1200000000000000000000000"},
+		{"1200000000000000000000000", 14, false, false, "1200000000000000000000000"},
+		{"1200000000000000000000000", 14, true, false, "1245621408203087110422302"},
+		{"1200000000000000000000000", 91, true, true, "1320358692695272395667416"},
+		{"1200000000000000000000000", 91, false, true, "1200000000000000000000000"},
+		{"3000000000000000000000000", 350, true, true, "3300896731738180989168541"},
+	} {
+		require.Equal(c.expected, calculateVoteWeight(policy, &iotextypes.VoteBucket{
+			StakedAmount:   c.amount,
+			StakedDuration: c.duration,
+			AutoStake:      c.autoStake,
+		}, c.selfStake).String())
+	}
+}